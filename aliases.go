@@ -0,0 +1,109 @@
+package bib
+
+import (
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/bobg/go-generics/v4/slices"
+)
+
+// WithAliases supplies a dictionary of expansions
+// for words and phrases in the input,
+// such as "NYC" → "New York City" or "Mr." → "Mister",
+// so that a library can encode its local filing rules.
+// An expansion may be empty, to ignore a word or phrase altogether.
+//
+// Both the aliases and their expansions are normalized like any other input
+// (so "Mr." and "mr" are the same alias)
+// and an alias matches only whole words:
+// "NYC" does not match "NYCHA."
+// Where aliases overlap, the longest match wins.
+// Expansions are not themselves subject to further expansion.
+//
+// The option may be given more than once;
+// the dictionaries are combined,
+// with later expansions replacing earlier ones for the same alias.
+func WithAliases(aliases map[string]string) Option {
+	return func(c *config) {
+		if c.aliases == nil {
+			c.aliases = make(map[string]string)
+		}
+		maps.Copy(c.aliases, aliases)
+	}
+}
+
+// alias is a normalized entry from the dictionary given to [WithAliases].
+type alias struct {
+	from, to []string
+}
+
+// compileAliases normalizes the dictionary in cfg,
+// indexing the result by the first word of each alias.
+// Each index entry is sorted longest alias first.
+func compileAliases(cfg config) map[string][]alias {
+	aliases := cfg.aliases
+	cfg.aliases, cfg.resolver = nil, nil
+	plain := &Keyer{cfg: cfg}
+	texts := func(s string) []string {
+		return slices.Map(plain.tokens(s), func(t token) string { return t.text })
+	}
+
+	result := make(map[string][]alias)
+	for from, to := range aliases {
+		a := alias{from: texts(from), to: texts(to)}
+		if len(a.from) == 0 {
+			continue
+		}
+		result[a.from[0]] = append(result[a.from[0]], a)
+	}
+	for _, list := range result {
+		sort.Slice(list, func(i, j int) bool {
+			if len(list[i].from) != len(list[j].from) {
+				return len(list[i].from) > len(list[j].from)
+			}
+			return strings.Join(list[i].from, " ") < strings.Join(list[j].from, " ")
+		})
+	}
+	return result
+}
+
+// expandAliases replaces the aliases in toks with their expansions.
+// Each expansion's tokens span the input of the words they replace.
+func (k *Keyer) expandAliases(toks []token) []token {
+	var result []token
+	for i := 0; i < len(toks); {
+		a, ok := k.matchAlias(toks[i:])
+		if !ok {
+			result = append(result, toks[i])
+			i++
+			continue
+		}
+		start, end := toks[i].start, toks[i+len(a.from)-1].end
+		for _, w := range a.to {
+			result = append(result, token{text: w, start: start, end: end})
+		}
+		i += len(a.from)
+	}
+	return result
+}
+
+// matchAlias finds the longest alias matching a prefix of toks.
+func (k *Keyer) matchAlias(toks []token) (alias, bool) {
+	for _, a := range k.aliases[toks[0].text] {
+		if len(a.from) > len(toks) {
+			continue
+		}
+		match := true
+		for j, w := range a.from {
+			if toks[j].text != w {
+				match = false
+				break
+			}
+		}
+		if match {
+			return a, true
+		}
+	}
+	return alias{}, false
+}
@@ -0,0 +1,55 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAliases(t *testing.T) {
+	k := NewKeyer(
+		WithAliases(map[string]string{
+			"NYC":       "New York City",
+			"Mr.":       "Mister",
+			"St":        "Saint",
+			"St. Louis": "Saint Louis Missouri",
+			"Inc.":      "",
+		}),
+		WithAliases(map[string]string{
+			"St": "Street",
+		}),
+	)
+
+	cases := []struct {
+		inp  string
+		want string
+	}{{
+		inp:  "NYC Marathon",
+		want: "new york city marathon",
+	}, {
+		inp:  "NYCHA Annual Report",
+		want: "nycha annual report",
+	}, {
+		inp:  "Mr. Smith Goes to Washington",
+		want: "mister smith goes to washington",
+	}, {
+		inp:  "The Mr Men",
+		want: "mister men",
+	}, {
+		inp:  "Baker St. Irregulars",
+		want: "baker street irregulars",
+	}, {
+		inp:  "Meet Me in St. Louis",
+		want: "meet me in saint louis missouri",
+	}, {
+		inp:  "Acme, Inc. Annual Report",
+		want: "acme annual report",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got := k.Key(tc.inp); got != tc.want {
+				t.Errorf(`input "%s", got "%s", want "%s"`, tc.inp, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package bib
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// AlphanumericPolicy says how a [Keyer] treats words that mix letters and digits,
+// like "3D," "U2," and "MP3."
+type AlphanumericPolicy int
+
+const (
+	// AlphanumericsAsWritten files such a word as written:
+	// "U2" files as "u2,"
+	// after "u" and before "ubiquity"
+	// (since digits precede letters).
+	// This is the default.
+	AlphanumericsAsWritten AlphanumericPolicy = iota
+
+	// AlphanumericsSpelled splits such a word into its runs of letters and of digits
+	// and spells out each run of digits,
+	// wherever the word appears:
+	// "U2" files as "u two"
+	// and "3D Printing" as "three d printing."
+	// With a [NumberMode] other than [NumbersSpelled],
+	// the digits are not spelled out,
+	// but a leading run of them is converted like any other leading number.
+	// Ordinals and decades like "10th" and "1980s" are not split.
+	// A word like "Catch-22" is already two words,
+	// "catch 22,"
+	// whose number is converted only if it leads.
+	AlphanumericsSpelled
+)
+
+// WithAlphanumerics sets the policy for words that mix letters and digits.
+// The default is [AlphanumericsAsWritten].
+func WithAlphanumerics(p AlphanumericPolicy) Option {
+	return func(c *config) {
+		c.alphanumerics = p
+	}
+}
+
+// alphanumeric splits w,
+// a normalized word,
+// for [AlphanumericsSpelled].
+// It reports false if w does not mix letters and digits,
+// or is an ordinal or decade.
+func (k *Keyer) alphanumeric(w string) ([]string, bool) {
+	if strings.IndexFunc(w, isASCIIDigit) < 0 || strings.IndexFunc(w, unicode.IsLetter) < 0 {
+		return nil, false
+	}
+	if numRegex.MatchString(w) || decadeRegex.MatchString(w) {
+		return nil, false
+	}
+
+	var result []string
+	for w != "" {
+		if n := runLen(w, isASCIIDigit); n > 0 {
+			result = append(result, k.digitRun(w[:n])...)
+			w = w[n:]
+			continue
+		}
+		n := runLen(w, func(r rune) bool { return !isASCIIDigit(r) })
+		result = append(result, w[:n])
+		w = w[n:]
+	}
+	return result, true
+}
+
+// digitRun converts a run of digits in a word for [AlphanumericsSpelled].
+func (k *Keyer) digitRun(digits string) []string {
+	if k.cfg.numberMode != NumbersSpelled {
+		return []string{digits}
+	}
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return []string{digits}
+	}
+	return k.spellInt(n, false, false)
+}
@@ -0,0 +1,74 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAlphanumerics(t *testing.T) {
+	spelled := WithAlphanumerics(AlphanumericsSpelled)
+
+	cases := []struct {
+		opts []Option
+		inp  string
+		want string
+	}{{
+		inp:  "3D Printing",
+		want: "3d printing",
+	}, {
+		inp:  "U2",
+		want: "u2",
+	}, {
+		inp:  "Catch-22",
+		want: "catch 22",
+	}, {
+		opts: []Option{spelled},
+		inp:  "3D Printing",
+		want: "three d printing",
+	}, {
+		opts: []Option{spelled},
+		inp:  "U2",
+		want: "u two",
+	}, {
+		opts: []Option{spelled},
+		inp:  "The MP3 Book",
+		want: "mp three book",
+	}, {
+		opts: []Option{spelled},
+		inp:  "R2D2",
+		want: "r two d two",
+	}, {
+		opts: []Option{spelled},
+		inp:  "Catch-22",
+		want: "catch 22",
+	}, {
+		opts: []Option{spelled},
+		inp:  "10th Kingdom",
+		want: "tenth kingdom",
+	}, {
+		opts: []Option{spelled},
+		inp:  "The 1980s",
+		want: "nineteen eighties",
+	}, {
+		opts: []Option{spelled, WithNumberMode(NumbersAsWritten)},
+		inp:  "U2",
+		want: "u 2",
+	}, {
+		opts: []Option{spelled, WithNumberMode(NumbersByValue)},
+		inp:  "3D Printing",
+		want: "013 d printing",
+	}, {
+		opts: []Option{spelled, WithNumberMode(NumbersByValue)},
+		inp:  "U2",
+		want: "u 2",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(tc.opts...).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
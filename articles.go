@@ -0,0 +1,180 @@
+package bib
+
+import (
+	"maps"
+	"strings"
+	"unicode"
+
+	"github.com/bobg/go-generics/v4/slices"
+)
+
+// WithArticles sets the words that a [Keyer] ignores
+// at the start of its input,
+// in place of "a," "an," and "the,"
+// e.g. to file titles in another language.
+// With no arguments,
+// no leading word is ignored.
+// The words are matched without regard to case.
+// An article marked in parentheses or brackets,
+// as in "(The) Gumball Rally,"
+// is always ignored.
+func WithArticles(articles ...string) Option {
+	return func(c *config) {
+		c.articles = make(map[string]bool)
+		for _, a := range articles {
+			c.articles[strings.ToLower(a)] = true
+		}
+	}
+}
+
+// WithOneLetterInvertedArticles makes a [Keyer] ignore
+// a single-letter article moved to the end after a comma,
+// as in "Man Called Ove, A,"
+// as it does longer ones like the "The" of "Hobbit, The."
+// Without it,
+// a single letter at the end is kept,
+// since it is more often a word or an initial,
+// as in "Smith, A" or "Bang, A."
+// Even with it,
+// a single letter with a period is taken to be an initial,
+// as in "Smith, A."
+func WithOneLetterInvertedArticles() Option {
+	return func(c *config) {
+		c.oneLetterInverted = true
+	}
+}
+
+// FirstArticle reports whether [Key] would ignore a leading article in s,
+// and if so returns the article and the rest of s
+// as written,
+// so that e.g. "The Beatles" can be displayed as "Beatles, The."
+// It uses the default [Keyer] (see [SetDefault]).
+func FirstArticle(s string) (article, rest string, ok bool) {
+	return Default().FirstArticle(s)
+}
+
+// FirstArticle reports whether [Keyer.Key] would ignore a leading article in s,
+// and if so returns the article and the rest of s
+// as written.
+// Punctuation around the article is not part of it,
+// so "(The) Gumball Rally" yields "The" and "Gumball Rally."
+// With [WithResolver], [WithMarkupStripping], or [WithParallelTitles],
+// the results are taken from the resolved string,
+// with its markup removed,
+// or the chosen parallel title.
+func (k *Keyer) FirstArticle(s string) (article, rest string, ok bool) {
+	s = k.prepare(s)
+	toks := k.split(s)
+	if len(toks) < 2 || !k.isArticle(toks[0]) {
+		return "", "", false
+	}
+	article = strings.TrimFunc(s[toks[0].start:toks[0].end], func(r rune) bool { return !unicode.IsLetter(r) })
+	rest = strings.TrimSpace(s[toks[1].start:])
+	return article, rest, true
+}
+
+// Articles returns the words that [Key] ignores at the start of its input,
+// in lower case and sorted.
+// It uses the default [Keyer] (see [SetDefault]).
+func Articles() []string {
+	return Default().Articles()
+}
+
+// Articles returns the words that [Keyer.Key] ignores at the start of its input,
+// in lower case and sorted.
+// An article marked in parentheses or brackets is ignored even if it is not among these
+// (see [WithArticles]).
+func (k *Keyer) Articles() []string {
+	if k.cfg.articles == nil {
+		return slices.Clone(defaultArticles)
+	}
+	return slices.Sorted(maps.Keys(k.cfg.articles))
+}
+
+// HasLeadingArticle tells whether [Key] ignores a leading article in s,
+// so that s is filed under a word other than its first,
+// as "The Gumball Rally" is filed under "Gumball."
+// It uses the default [Keyer] (see [SetDefault]).
+func HasLeadingArticle(s string) bool {
+	return Default().HasLeadingArticle(s)
+}
+
+// HasLeadingArticle tells whether [Keyer.Key] ignores a leading article in s.
+// See [HasLeadingArticle] and [Keyer.FirstArticle].
+func (k *Keyer) HasLeadingArticle(s string) bool {
+	_, _, ok := k.FirstArticle(s)
+	return ok
+}
+
+// NonfilingPrefixLen returns the length in bytes of the part of s that [Key] ignores,
+// i.e. the offset in s where filing begins:
+// 4 for "The Gumball Rally,"
+// and 0 if s has no leading article.
+// A UI might use it to dim the nonfiling prefix in an alphabetized list.
+// For the length in runes, use [unicode/utf8.RuneCountInString] on s[:n].
+// It uses the default [Keyer] (see [SetDefault]).
+func NonfilingPrefixLen(s string) int {
+	return Default().NonfilingPrefixLen(s)
+}
+
+// NonfilingPrefixLen returns the length in bytes of the part of s that [Keyer.Key] ignores.
+// See [NonfilingPrefixLen].
+// With [WithResolver], [WithMarkupStripping], or [WithParallelTitles],
+// the length is that of the prefix of the resolved string,
+// with its markup removed,
+// or the chosen parallel title.
+func (k *Keyer) NonfilingPrefixLen(s string) int {
+	toks := k.tokens(s)
+	if len(toks) < 2 || !k.isArticle(toks[0]) {
+		return 0
+	}
+	return toks[1].start
+}
+
+// DisplayForm returns s with a leading article that [Key] ignores
+// moved to the end after a comma,
+// as "The Gumball Rally" becomes "Gumball Rally, The,"
+// the conventional form for alphabetized lists.
+// The case and punctuation of s are kept,
+// except for punctuation around the article
+// (so "(The) Gumball Rally" also becomes "Gumball Rally, The").
+// If s has no such article it is returned unchanged.
+// A [Keyer] gives the result the same key as s,
+// except that for a single-letter article like "A"
+// it must have [WithOneLetterInvertedArticles].
+// It uses the default [Keyer] (see [SetDefault]).
+func DisplayForm(s string) string {
+	return Default().DisplayForm(s)
+}
+
+// DisplayForm returns s with a leading article that [Keyer.Key] ignores
+// moved to the end after a comma.
+// See [DisplayForm] and [Keyer.FirstArticle].
+func (k *Keyer) DisplayForm(s string) string {
+	article, rest, ok := k.FirstArticle(s)
+	if !ok {
+		return s
+	}
+	return rest + ", " + article
+}
+
+// TitlePrefix splits a title into a leading article that [Key] ignores, if any,
+// and the rest,
+// each as written,
+// for the ONIX TitlePrefix and TitleWithoutPrefix elements.
+// If s has no such article,
+// prefix is empty and withoutPrefix is s with surrounding space removed.
+// It uses the default [Keyer] (see [SetDefault]).
+func TitlePrefix(s string) (prefix, withoutPrefix string) {
+	return Default().TitlePrefix(s)
+}
+
+// TitlePrefix splits a title into a leading article that [Keyer.Key] ignores, if any,
+// and the rest.
+// See [TitlePrefix].
+func (k *Keyer) TitlePrefix(s string) (prefix, withoutPrefix string) {
+	if article, rest, ok := k.FirstArticle(s); ok {
+		return article, rest
+	}
+	return "", strings.TrimSpace(s)
+}
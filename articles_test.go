@@ -0,0 +1,266 @@
+package bib
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFirstArticle(t *testing.T) {
+	cases := []struct {
+		inp           string
+		article, rest string
+		ok            bool
+	}{{
+		inp:     "The Beatles",
+		article: "The",
+		rest:    "Beatles",
+		ok:      true,
+	}, {
+		inp:     "  an   Officer and a Gentleman ",
+		article: "an",
+		rest:    "Officer and a Gentleman",
+		ok:      true,
+	}, {
+		inp:     "(The) Gumball Rally",
+		article: "The",
+		rest:    "Gumball Rally",
+		ok:      true,
+	}, {
+		inp:     "[A]Clockwork Orange",
+		article: "A",
+		rest:    "Clockwork Orange",
+		ok:      true,
+	}, {
+		inp: "The",
+	}, {
+		inp: "Theory of Everything",
+	}, {
+		inp: "A.I. Artificial Intelligence",
+	}, {
+		inp: "",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			article, rest, ok := FirstArticle(tc.inp)
+			if article != tc.article || rest != tc.rest || ok != tc.ok {
+				t.Errorf(`got "%s", "%s", %v; want "%s", "%s", %v`, article, rest, ok, tc.article, tc.rest, tc.ok)
+			}
+		})
+	}
+}
+
+// TestFirstArticleMatchesKey checks that FirstArticle finds an article
+// exactly when Key ignores one.
+func TestFirstArticleMatchesKey(t *testing.T) {
+	inps := []string{
+		"The Gumball Rally",
+		"THE END",
+		"A Tale of Two Cities",
+		"(The) Gumball Rally",
+		"An  Officer and a Gentleman",
+		"The",
+		"The 39 Steps",
+		"Hobbit, The",
+		"A.I. Artificial Intelligence",
+		"Theory of Everything",
+	}
+	for i, inp := range inps {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			article, rest, ok := FirstArticle(inp)
+			if ok {
+				if got, want := Key(inp), Key(rest); got != want {
+					t.Errorf("FirstArticle found %q, but Key is %q, not %q", article, got, want)
+				}
+				return
+			}
+			if _, after, found := strings.Cut(inp, " "); found && Key(inp) == Key(after) {
+				t.Errorf("FirstArticle found no article, but Key ignores the first word")
+			}
+		})
+	}
+}
+
+func TestWithArticles(t *testing.T) {
+	cases := []struct {
+		articles  []string
+		inp, want string
+	}{{
+		articles: []string{"le", "la", "les", "un", "une"},
+		inp:      "Les Misérables",
+		want:     "misérables",
+	}, {
+		articles: []string{"le", "la", "les", "un", "une"},
+		inp:      "The Misanthrope",
+		want:     "the misanthrope",
+	}, {
+		articles: []string{"Der", "Die", "Das"},
+		inp:      "Die Verwandlung",
+		want:     "verwandlung",
+	}, {
+		articles: nil,
+		inp:      "The Beatles",
+		want:     "the beatles",
+	}, {
+		articles: nil,
+		inp:      "(The) Gumball Rally",
+		want:     "gumball rally",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := KeyWith(tc.inp, WithArticles(tc.articles...))
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestArticles(t *testing.T) {
+	if got, want := Articles(), []string{"a", "an", "the"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	k := NewKeyer(WithArticles("Les", "la", "LE"))
+	if got, want := k.Articles(), []string{"la", "le", "les"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("with WithArticles, got %q, want %q", got, want)
+	}
+
+	if got := NewKeyer(WithArticles()).Articles(); len(got) != 0 {
+		t.Errorf("with no articles, got %q", got)
+	}
+}
+
+func TestHasLeadingArticle(t *testing.T) {
+	cases := []struct {
+		inp  string
+		want bool
+	}{{
+		inp:  "The Gumball Rally",
+		want: true,
+	}, {
+		inp:  "(A) Clockwork Orange",
+		want: true,
+	}, {
+		inp:  "Gumball Rally, The",
+		want: false,
+	}, {
+		inp:  "Theory of Everything",
+		want: false,
+	}, {
+		inp:  "The",
+		want: false,
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got := HasLeadingArticle(tc.inp); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNonfilingPrefixLen(t *testing.T) {
+	cases := []struct {
+		inp  string
+		want int
+	}{{
+		inp:  "The Gumball Rally",
+		want: 4,
+	}, {
+		inp:  "  An   Officer and a Gentleman",
+		want: 7,
+	}, {
+		inp:  "(The) Gumball Rally",
+		want: 6,
+	}, {
+		inp:  "[A]Clockwork Orange",
+		want: 3,
+	}, {
+		inp:  "Gumball Rally, The",
+		want: 0,
+	}, {
+		inp:  "The",
+		want: 0,
+	}, {
+		inp:  "",
+		want: 0,
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got := NonfilingPrefixLen(tc.inp); got != tc.want {
+				t.Errorf("got %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDisplayForm(t *testing.T) {
+	cases := []struct {
+		inp, want string
+	}{{
+		inp:  "The Gumball Rally",
+		want: "Gumball Rally, The",
+	}, {
+		inp:  "THE END",
+		want: "END, THE",
+	}, {
+		inp:  "an Officer and a Gentleman",
+		want: "Officer and a Gentleman, an",
+	}, {
+		inp:  "(The) Gumball Rally",
+		want: "Gumball Rally, The",
+	}, {
+		inp:  "A Bug's Life!",
+		want: "Bug's Life!, A",
+	}, {
+		inp:  "Gumball Rally, The",
+		want: "Gumball Rally, The",
+	}, {
+		inp:  "Theory of Everything",
+		want: "Theory of Everything",
+	}}
+
+	k := NewKeyer(WithOneLetterInvertedArticles())
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got := DisplayForm(tc.inp); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+			if k.Key(tc.inp) != k.Key(tc.want) {
+				t.Errorf("key of %q differs from that of %q", tc.want, tc.inp)
+			}
+		})
+	}
+}
+
+func TestTitlePrefix(t *testing.T) {
+	cases := []struct {
+		inp, prefix, withoutPrefix string
+	}{{
+		inp:           "The Gumball Rally",
+		prefix:        "The",
+		withoutPrefix: "Gumball Rally",
+	}, {
+		inp:           "(A) Clockwork Orange",
+		prefix:        "A",
+		withoutPrefix: "Clockwork Orange",
+	}, {
+		inp:           " 42nd Street ",
+		withoutPrefix: "42nd Street",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			prefix, withoutPrefix := TitlePrefix(tc.inp)
+			if prefix != tc.prefix || withoutPrefix != tc.withoutPrefix {
+				t.Errorf("got %q, %q; want %q, %q", prefix, withoutPrefix, tc.prefix, tc.withoutPrefix)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 // Package bib contains functions for (English-language) bibliographic sorting of strings.
 //
-// A bibliographic sort is one that ignores a leading article ("the," "a," "an")
+// A bibliographic sort is one that ignores a leading article ("the," "a," "an"),
+// or one of more than one letter moved to the end as in "Hobbit, The,"
 // and treats leading numbers as if they're spelled out.
 // Characters other than letters and digits are ignored,
 // except that "&" is converted to the spelled-out word "and,"
@@ -9,78 +10,127 @@ package bib
 
 import (
 	"regexp"
-	"sort"
-	"strconv"
 	"strings"
-	"unicode"
-
-	"github.com/bobg/go-generics/v4/slices"
 )
 
 // Less tells whether a comes before b in a bibliograhic sort.
 func Less(a, b string) bool {
-	return Key(a) < Key(b)
+	return Default().Less(a, b)
+}
+
+// Compare returns -1, 0, or 1
+// according to whether a sorts before, the same as, or after b
+// in a bibliographic sort,
+// for use with e.g. [slices.SortFunc].
+// Strings sort the same when their keys are equal
+// (unless the default Keyer has [WithTieBreak]).
+// It uses the default [Keyer] (see [SetDefault]).
+func Compare(a, b string) int {
+	return Default().Compare(a, b)
+}
+
+// Equal tells whether a and b have the same key,
+// as "The Hobbit" and "Hobbit, The" do.
+// It uses the default [Keyer] (see [SetDefault]).
+func Equal(a, b string) bool {
+	return Default().Equal(a, b)
 }
 
 // Sort sorts the input slice bibliographically.
 func Sort(strs []string) {
-	// We could just write:
-	//
-	//   sort.Slice(strs, func(i, j int) bool { return Less(strs[i], strs[j]) })
-	//
-	// but that would call Key on each string in strs more than once, on average,
-	// which is inefficient.
-	// So instead we compute keys for all the strings exactly once into a new slice,
-	// then use slices.KeyedSort.
-
-	keys := slices.Map(strs, Key)
-	slices.KeyedSort(strs, sort.StringSlice(keys))
+	Default().Sort(strs)
 }
 
 // Key converts an input string to a bibliographic sort key.
+// It uses the default [Keyer] (see [SetDefault]).
 func Key(s string) string {
-	s = strings.TrimSpace(s)
-	s = strings.ToLower(s)
-	s = strings.ReplaceAll(s, "&", " and ")
-	s = strings.Map(dashToSpace, s)
-	s = strings.Map(keepLettersDigitsWhitespace, s)
-
-	f := strings.Fields(s)
-	switch f[0] {
-	case "a", "the", "an":
-		if len(f) == 1 {
-			// Unlikely case.
-			return f[0]
-		}
-		f = f[1:]
-	}
+	return Default().Key(s)
+}
 
-	m := numRegex.FindStringSubmatch(f[0])
-	if len(m) > 0 {
-		n, _ := strconv.ParseInt(m[1], 10, 64)
-		f = slices.ReplaceN(f, 0, 1, intToWords(n, len(m[2]) > 0)...)
-	}
+// KeyWith is like [Key]
+// but adds the given options to those of the default [Keyer],
+// as in
+//
+//	KeyWith(s, WithArticles("le", "la", "les"), WithNumberMode(NumbersByValue))
+//
+// To key many strings with the same options,
+// it is more efficient to make a Keyer with [NewKeyer] or [Keyer.With].
+func KeyWith(s string, opts ...Option) string {
+	return Default().With(opts...).Key(s)
+}
 
-	return strings.Join(f, " ")
+// SortWith is like [Sort]
+// but adds the given options to those of the default [Keyer].
+func SortWith(strs []string, opts ...Option) {
+	Default().With(opts...).Sort(strs)
 }
 
-func dashToSpace(r rune) rune {
-	if unicode.In(r, unicode.Pd) {
-		return ' '
+var numRegex = regexp.MustCompile(`^(\d+)(st|nd|rd|th)?$`)
+
+// decadeRegex matches a decade like "1960s,"
+// or "60s," which is also what "'60s" becomes once its punctuation is removed.
+var decadeRegex = regexp.MustCompile(`^(\d*[1-9]0|\d+00)s$`)
+
+// decadeToWords spells out a decade according to k's options:
+// 1960 is "nineteen sixties" and 1900 is "nineteen hundreds."
+func (k *Keyer) decadeToWords(n int64) []string {
+	w := k.intToWords(n, false)
+	last := &w[len(w)-1]
+	if strings.HasSuffix(*last, "y") {
+		*last = strings.TrimSuffix(*last, "y") + "ies"
+	} else {
+		*last += "s"
 	}
-	return r
+	return w
+}
+
+func intToWords(n int64, ordinal bool) []string {
+	return spellInt(n, ordinal, spelling{years: true})
 }
 
-func keepLettersDigitsWhitespace(r rune) rune {
-	if unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsSpace(r) {
-		return r
+// spelling holds the choices for spelling out a number.
+type spelling struct {
+	// years is true if numbers in the year ranges
+	// are spelled as years,
+	// as in "nineteen seventeen."
+	years bool
+
+	// yearRanges are the ranges for years.
+	// If this is nil, defaultYearRanges is used.
+	yearRanges []YearRange
+
+	// oh is true if years like 1907 are spelled "nineteen oh seven"
+	// rather than "nineteen hundred seven."
+	oh bool
+
+	// and is true for the British "and" before the last part of a number
+	// below one hundred,
+	// as in "one hundred and one."
+	and bool
+}
+
+// isYear tells whether n is in one of the year ranges of sp.
+func (sp spelling) isYear(n int64) bool {
+	ranges := sp.yearRanges
+	if ranges == nil {
+		ranges = defaultYearRanges
+	}
+	for _, r := range ranges {
+		if n >= r.From && n <= r.To {
+			return true
+		}
 	}
-	return -1
+	return false
 }
 
-var numRegex = regexp.MustCompile(`^(\d+)(st|nd|rd|th)?$`)
+// spellInt spells out n,
+// as an ordinal if ordinal is true,
+// according to sp.
+func spellInt(n int64, ordinal bool, sp spelling) []string {
+	// The parts of a number are never years:
+	// 1001984 is "one million one thousand nine hundred eighty-four."
+	parts := spelling{and: sp.and}
 
-func intToWords(n int64, ordinal bool) []string {
 	if ordinal && n < 10 {
 		var x string
 
@@ -100,7 +150,7 @@ func intToWords(n int64, ordinal bool) []string {
 		case 9:
 			x = "ninth"
 		default:
-			w := intToWords(n, false)
+			w := spellInt(n, false, parts)
 			x = w[0] + "th"
 		}
 		return []string{x}
@@ -151,7 +201,11 @@ func intToWords(n int64, ordinal bool) []string {
 		case 19:
 			x = "nineteen"
 		}
-		if ordinal {
+		switch {
+		case !ordinal:
+		case n == 12:
+			x = "twelfth"
+		default:
 			// Won't be true for 0 through 9, which are handled above.
 			x += "th"
 		}
@@ -207,7 +261,7 @@ func intToWords(n int64, ordinal bool) []string {
 			s = "ninety"
 		}
 		if r := n % 10; r > 0 {
-			w := intToWords(r, ordinal)
+			w := spellInt(r, ordinal, parts)
 			s += "-" + w[0]
 		}
 		return []string{s}
@@ -215,10 +269,13 @@ func intToWords(n int64, ordinal bool) []string {
 
 	if n < 1000 {
 		q, r := n/100, n%100 // quotient, remainder
-		w := intToWords(q, false)
+		w := spellInt(q, false, parts)
 		w = append(w, "hundred")
 		if r > 0 {
-			ww := intToWords(r, ordinal)
+			if sp.and {
+				w = append(w, "and")
+			}
+			ww := spellInt(r, ordinal, parts)
 			w = append(w, ww...)
 		}
 		if ordinal && r == 0 {
@@ -228,14 +285,20 @@ func intToWords(n int64, ordinal bool) []string {
 	}
 
 	// Years.
-	if !ordinal && n >= 1100 && n < 3000 && !((n >= 2000) && (n < 2010)) {
+	if sp.years && !ordinal && n < 10000 && sp.isYear(n) {
 		q, r := n/100, n%100
-		w := intToWords(q, false)
-		if r < 10 {
+		w := spellInt(q, false, parts)
+		switch {
+		case r > 0 && r < 10 && sp.oh:
+			w = append(w, "oh")
+		case r < 10:
 			w = append(w, "hundred")
+			if r > 0 && sp.and {
+				w = append(w, "and")
+			}
 		}
 		if r > 0 {
-			ww := intToWords(r, false)
+			ww := spellInt(r, false, parts)
 			w = append(w, ww...)
 		}
 		return w
@@ -243,13 +306,16 @@ func intToWords(n int64, ordinal bool) []string {
 
 	if n < 1000000 {
 		q, r := n/1000, n%1000
-		w := intToWords(q, false)
+		w := spellInt(q, false, parts)
 		w = append(w, "thousand")
 		if r > 0 {
-			ww := intToWords(r, ordinal)
+			if r < 100 && sp.and {
+				w = append(w, "and")
+			}
+			ww := spellInt(r, ordinal, parts)
 			w = append(w, ww...)
 		}
-		if ordinal && n%100 == 0 {
+		if ordinal && r == 0 {
 			w[len(w)-1] += "th"
 		}
 		return w
@@ -257,26 +323,32 @@ func intToWords(n int64, ordinal bool) []string {
 
 	if n < 1000000000 {
 		q, r := n/1000000, n%1000000
-		w := intToWords(q, false)
+		w := spellInt(q, false, parts)
 		w = append(w, "million")
 		if r > 0 {
-			ww := intToWords(r, ordinal)
+			if r < 100 && sp.and {
+				w = append(w, "and")
+			}
+			ww := spellInt(r, ordinal, parts)
 			w = append(w, ww...)
 		}
-		if ordinal && n%100 == 0 {
+		if ordinal && r == 0 {
 			w[len(w)-1] += "th"
 		}
 		return w
 	}
 
 	q, r := n/1000000000, n%1000000000
-	w := intToWords(q, false)
+	w := spellInt(q, false, parts)
 	w = append(w, "billion")
 	if r > 0 {
-		ww := intToWords(r, ordinal)
+		if r < 100 && sp.and {
+			w = append(w, "and")
+		}
+		ww := spellInt(r, ordinal, parts)
 		w = append(w, ww...)
 	}
-	if ordinal && n%100 == 0 {
+	if ordinal && r == 0 {
 		w[len(w)-1] += "th"
 	}
 	return w
@@ -81,3 +81,76 @@ func TestSort(t *testing.T) {
 		t.Errorf("got %v, want %v", x, want)
 	}
 }
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{{
+		a:    "The Gumball Rally",
+		b:    "Gumball Rally",
+		want: 0,
+	}, {
+		a:    "9 to 5",
+		b:    "1917",
+		want: -1,
+	}, {
+		a:    "Zebra",
+		b:    "An Aardvark",
+		want: 1,
+	}, {
+		a:    "",
+		b:    "A",
+		want: -1,
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got := Compare(tc.a, tc.b); got != tc.want {
+				t.Errorf("got %d, want %d", got, tc.want)
+			}
+			if got := Compare(tc.b, tc.a); got != -tc.want {
+				t.Errorf("reversed, got %d, want %d", got, -tc.want)
+			}
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{{
+		a:    "The Hobbit",
+		b:    "Hobbit, The",
+		want: true,
+	}, {
+		a:    "9 to 5",
+		b:    "Nine to 5",
+		want: true,
+	}, {
+		a:    "Rock & Roll",
+		b:    "rock and roll!",
+		want: true,
+	}, {
+		a:    "The Hobbit",
+		b:    "The Hobbits",
+		want: false,
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got := Equal(tc.a, tc.b); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKeyEmpty(t *testing.T) {
+	for _, inp := range []string{"", "  "} {
+		if got := Key(inp); got != "" {
+			t.Errorf(`input "%s", got "%s", want ""`, inp, got)
+		}
+	}
+}
@@ -0,0 +1,360 @@
+// Package calibre recomputes the sort fields of a Calibre library
+// according to bibliographic filing rules.
+//
+// Calibre shows these fields to users,
+// so [Book.Recompute] writes them as display strings in Calibre's own style,
+// not sort keys:
+// a title's leading article is moved to the end ("Hobbit, The")
+// and an author's name is inverted ("Tolkien, J. R. R."),
+// with case and punctuation kept.
+// These are the forms Calibre computes by default,
+// so Recompute files books no differently than Calibre does;
+// it only repairs fields edited by hand or computed with other settings.
+//
+// [Book.RecomputeKeys] instead writes sort keys,
+// so that Calibre files books by this module's rules,
+// with numbers spelled out and punctuation ignored,
+// at the cost of showing the keys in Calibre's sort fields.
+//
+// Calibre keeps a metadata.opf file alongside each book
+// in addition to its metadata.db database.
+// This package reads and rewrites those OPF files,
+// updating the calibre:title_sort meta element
+// and the opf:file-as attribute of each author.
+// Calibre treats metadata.db as authoritative,
+// so after rewriting OPF files,
+// use Calibre's "Restore database" library-maintenance action
+// to have it pick up the changes.
+package calibre
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bobg/bib"
+	"github.com/bobg/bib/internal/xmlsplice"
+)
+
+const (
+	dcNS          = "http://purl.org/dc/elements/1.1/"
+	titleSortMeta = "calibre:title_sort"
+)
+
+// Book is the sort-related metadata of one book in a Calibre library.
+type Book struct {
+	// Path is the path of the book's metadata.opf file, if known.
+	Path string
+
+	Title     string
+	TitleSort string
+	Authors   []Author
+
+	src            []byte
+	titleStart     int  // offset of the dc:title start tag
+	titleSortTag   span // the calibre:title_sort meta element, if present
+	metadataEnd    int  // offset of the </metadata> end tag
+	hasTitleSort   bool
+	authorTagSpans []span
+}
+
+// Author is one author of a book.
+type Author struct {
+	Name string
+
+	// Sort is the author's sort name
+	// (the opf:file-as attribute).
+	Sort string
+}
+
+type span struct{ start, end int }
+
+// ParseOPF parses the contents of a Calibre metadata.opf file.
+func ParseOPF(data []byte) (*Book, error) {
+	b := &Book{src: data, titleStart: -1, metadataEnd: -1}
+
+	var (
+		dec     = xml.NewDecoder(bytes.NewReader(data))
+		inTitle bool
+		author  *Author // non-nil while inside an author's dc:creator element
+		text    strings.Builder
+	)
+	for {
+		start := int(dec.InputOffset())
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing OPF: %w", err)
+		}
+		end := int(dec.InputOffset())
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			switch {
+			case tok.Name.Space == dcNS && tok.Name.Local == "title" && b.titleStart < 0:
+				inTitle = true
+				b.titleStart = start
+				text.Reset()
+
+			case tok.Name.Space == dcNS && tok.Name.Local == "creator":
+				role := attr(tok, "role")
+				if role != "" && role != "aut" {
+					break
+				}
+				b.Authors = append(b.Authors, Author{Sort: attr(tok, "file-as")})
+				author = &b.Authors[len(b.Authors)-1]
+				b.authorTagSpans = append(b.authorTagSpans, span{start: start, end: end})
+				text.Reset()
+
+			case tok.Name.Local == "meta" && attr(tok, "name") == titleSortMeta:
+				b.TitleSort = attr(tok, "content")
+				b.titleSortTag = span{start: start, end: end}
+				b.hasTitleSort = true
+			}
+
+		case xml.EndElement:
+			switch {
+			case inTitle:
+				b.Title = strings.TrimSpace(text.String())
+				inTitle = false
+
+			case author != nil:
+				author.Name = strings.TrimSpace(text.String())
+				author = nil
+
+			case tok.Name.Local == "metadata":
+				b.metadataEnd = start
+			}
+
+		case xml.CharData:
+			if inTitle || author != nil {
+				text.Write(tok)
+			}
+		}
+	}
+
+	if b.metadataEnd < 0 {
+		return nil, fmt.Errorf("no metadata element in OPF")
+	}
+	return b, nil
+}
+
+func attr(el xml.StartElement, local string) string {
+	for _, a := range el.Attr {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// ReadOPF reads and parses the metadata.opf file at the given path.
+func ReadOPF(path string) (*Book, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	b, err := ParseOPF(data)
+	if err != nil {
+		return nil, fmt.Errorf("in %s: %w", path, err)
+	}
+	b.Path = path
+	return b, nil
+}
+
+// ReadLibrary reads every metadata.opf file in the Calibre library at dir.
+func ReadLibrary(dir string) ([]*Book, error) {
+	var books []*Book
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || d.Name() != "metadata.opf" {
+			return nil
+		}
+		b, err := ReadOPF(path)
+		if err != nil {
+			return err
+		}
+		books = append(books, b)
+		return nil
+	})
+	return books, err
+}
+
+// Recompute sets the book's TitleSort and author Sort fields
+// from their titles and names,
+// in the display form that Calibre uses for them
+// (see [bib.DisplayForm] and [AuthorSort]).
+// It reports whether any field changed.
+//
+// Calibre computes the same forms itself,
+// so Recompute mainly repairs fields that were edited by hand
+// or computed with other settings.
+// To have Calibre file books by this module's rules,
+// with numbers spelled out and punctuation ignored,
+// use [Book.RecomputeKeys].
+func (b *Book) Recompute() bool {
+	return b.set(bib.DisplayForm(strings.TrimSpace(b.Title)), AuthorSort)
+}
+
+// RecomputeKeys sets the book's TitleSort and author Sort fields
+// to the sort keys of their titles and names
+// (see [bib.Keyer.Key] and [bib.PersonKey]),
+// so that Calibre's sorting by those fields
+// files the books as k does:
+// "The 39 Steps" sorts as "thirty-nine steps,"
+// after "Thirteen Days" and before "3:10 to Yuma."
+// Calibre shows the keys to users as they are.
+// If k is nil,
+// RecomputeKeys uses the default Keyer (see [bib.SetDefault]).
+// It reports whether any field changed.
+func (b *Book) RecomputeKeys(k *bib.Keyer) bool {
+	if k == nil {
+		k = bib.Default()
+	}
+	return b.set(k.Key(b.Title), bib.PersonKey)
+}
+
+// set sets the book's TitleSort to titleSort
+// and each author's Sort to authorSort of the author's name,
+// reporting whether any field changed.
+func (b *Book) set(titleSort string, authorSort func(string) string) bool {
+	changed := false
+
+	if titleSort != b.TitleSort {
+		b.TitleSort = titleSort
+		changed = true
+	}
+	for i := range b.Authors {
+		if as := authorSort(b.Authors[i].Name); as != b.Authors[i].Sort {
+			b.Authors[i].Sort = as
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// AuthorSort computes a Calibre-style sort name for an author,
+// with the surname first and case and punctuation kept:
+// "Judd Apatow" is "Apatow, Judd"
+// and "Martin Luther King Jr." is "King, Martin Luther, Jr."
+// It is [bib.PersonDisplayForm].
+func AuthorSort(name string) string {
+	return bib.PersonDisplayForm(name)
+}
+
+// Bytes returns the OPF document
+// with the book's current TitleSort and author Sort values written into it.
+// Everything else in the document is left as it was.
+func (b *Book) Bytes() []byte {
+	var (
+		slots [][2]int
+		parts [][]byte
+	)
+
+	for i, s := range b.authorTagSpans {
+		slots = append(slots, [2]int{s.start, s.end})
+		parts = append(parts, setAttr(b.src[s.start:s.end], fileAsRegex, "opf:file-as", b.Authors[i].Sort))
+	}
+
+	if b.hasTitleSort {
+		s := b.titleSortTag
+		slots = append(slots, [2]int{s.start, s.end})
+		parts = append(parts, setAttr(b.src[s.start:s.end], contentRegex, "content", b.TitleSort))
+	} else if b.TitleSort != "" {
+		// Insert a new meta element just before </metadata>,
+		// on its own line and indented like dc:title.
+		var (
+			pos    = b.metadataEnd
+			indent = indentation(b.src, b.titleStart)
+		)
+		if i := bytes.LastIndexByte(b.src[:pos], '\n'); i >= 0 && len(bytes.TrimSpace(b.src[i:pos])) == 0 {
+			pos = i + 1
+		}
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, `%s<meta name="%s" content="%s"/>`, indent, titleSortMeta, escapeAttr(b.TitleSort))
+		if pos < b.metadataEnd {
+			buf.WriteString("\n")
+		}
+		slots = append(slots, [2]int{pos, pos})
+		parts = append(parts, buf.Bytes())
+	}
+
+	// The slots are in document order, except possibly the title_sort one.
+	for i := len(slots) - 1; i > 0 && slots[i][0] < slots[i-1][0]; i-- {
+		slots[i], slots[i-1] = slots[i-1], slots[i]
+		parts[i], parts[i-1] = parts[i-1], parts[i]
+	}
+
+	return xmlsplice.Splice(b.src, slots, parts)
+}
+
+// Write rewrites the book's metadata.opf file with the result of [Book.Bytes].
+func (b *Book) Write() error {
+	if b.Path == "" {
+		return fmt.Errorf("book has no path")
+	}
+	info, err := os.Stat(b.Path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(b.Path, b.Bytes(), info.Mode().Perm())
+}
+
+var (
+	fileAsRegex  = regexp.MustCompile(`\s[\w.-]*:?file-as\s*=\s*("[^"]*"|'[^']*')`)
+	contentRegex = regexp.MustCompile(`\scontent\s*=\s*("[^"]*"|'[^']*')`)
+)
+
+// setAttr sets an attribute in the start tag tag,
+// replacing the existing attribute matched by re
+// or adding a new one named name.
+func setAttr(tag []byte, re *regexp.Regexp, name, value string) []byte {
+	if loc := re.FindSubmatchIndex(tag); loc != nil {
+		// Replace just the quoted value, keeping the attribute's original name.
+		var out []byte
+		out = append(out, tag[:loc[2]]...)
+		out = append(out, '"')
+		out = append(out, escapeAttr(value)...)
+		out = append(out, '"')
+		return append(out, tag[loc[3]:]...)
+	}
+
+	end := len(tag) - 1 // the closing '>'
+	if end > 0 && tag[end-1] == '/' {
+		end--
+	}
+	var out []byte
+	out = append(out, tag[:end]...)
+	out = append(out, fmt.Sprintf(` %s="%s"`, name, escapeAttr(value))...)
+	return append(out, tag[end:]...)
+}
+
+func escapeAttr(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// indentation returns the whitespace preceding pos on its line,
+// or a default if there is something other than whitespace there.
+func indentation(src []byte, pos int) string {
+	if pos < 0 {
+		return "    "
+	}
+	line := src[bytes.LastIndexByte(src[:pos], '\n')+1 : pos]
+	if len(bytes.TrimSpace(line)) > 0 {
+		return "    "
+	}
+	return string(line)
+}
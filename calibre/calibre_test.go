@@ -0,0 +1,146 @@
+package calibre
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testOPF = `<?xml version='1.0' encoding='utf-8'?>
+<package xmlns="http://www.idpf.org/2007/opf" unique-identifier="uuid_id" version="2.0">
+    <metadata xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:opf="http://www.idpf.org/2007/opf">
+        <dc:identifier opf:scheme="calibre" id="calibre_id">1</dc:identifier>
+        <dc:title>The 40-Year-Old Virgin</dc:title>
+        <dc:creator opf:file-as="Apatow, Judd" opf:role="aut">Judd Apatow</dc:creator>
+        <dc:creator opf:role="aut">Martin Luther King Jr.</dc:creator>
+        <dc:creator opf:role="edt">Some Editor</dc:creator>
+        <meta name="calibre:timestamp" content="2020-01-01T00:00:00+00:00"/>
+    </metadata>
+</package>
+`
+
+const wantOPF = `<?xml version='1.0' encoding='utf-8'?>
+<package xmlns="http://www.idpf.org/2007/opf" unique-identifier="uuid_id" version="2.0">
+    <metadata xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:opf="http://www.idpf.org/2007/opf">
+        <dc:identifier opf:scheme="calibre" id="calibre_id">1</dc:identifier>
+        <dc:title>The 40-Year-Old Virgin</dc:title>
+        <dc:creator opf:file-as="Apatow, Judd" opf:role="aut">Judd Apatow</dc:creator>
+        <dc:creator opf:role="aut" opf:file-as="King, Martin Luther, Jr.">Martin Luther King Jr.</dc:creator>
+        <dc:creator opf:role="edt">Some Editor</dc:creator>
+        <meta name="calibre:timestamp" content="2020-01-01T00:00:00+00:00"/>
+        <meta name="calibre:title_sort" content="40-Year-Old Virgin, The"/>
+    </metadata>
+</package>
+`
+
+func TestRecompute(t *testing.T) {
+	dir := t.TempDir()
+	bookDir := filepath.Join(dir, "Judd Apatow", "The 40-Year-Old Virgin (1)")
+	if err := os.MkdirAll(bookDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(bookDir, "metadata.opf")
+	if err := os.WriteFile(path, []byte(testOPF), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	books, err := ReadLibrary(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(books) != 1 {
+		t.Fatalf("got %d books, want 1", len(books))
+	}
+	b := books[0]
+	if len(b.Authors) != 2 {
+		t.Fatalf("got %d authors, want 2", len(b.Authors))
+	}
+	if b.Authors[0].Sort != "Apatow, Judd" {
+		t.Errorf(`got author sort "%s", want "Apatow, Judd"`, b.Authors[0].Sort)
+	}
+
+	if !b.Recompute() {
+		t.Error("Recompute reported no change")
+	}
+	if err := b.Write(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != wantOPF {
+		t.Errorf("got:\n%s\nwant:\n%s", got, wantOPF)
+	}
+
+	// A second pass finds the sort fields already up to date.
+	b, err = ReadOPF(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Recompute() {
+		t.Error("Recompute reported a change on an updated file")
+	}
+	if string(b.Bytes()) != wantOPF {
+		t.Errorf("rewriting an updated file changed it:\n%s", b.Bytes())
+	}
+}
+
+func TestRecomputeKeys(t *testing.T) {
+	b, err := ParseOPF([]byte(testOPF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.RecomputeKeys(nil) {
+		t.Error("RecomputeKeys reported no change")
+	}
+	if want := "forty year old virgin"; b.TitleSort != want {
+		t.Errorf(`got title sort "%s", want "%s"`, b.TitleSort, want)
+	}
+	for i, want := range []string{"apatow judd", "king martin luther jr"} {
+		if b.Authors[i].Sort != want {
+			t.Errorf(`got author %d sort "%s", want "%s"`, i, b.Authors[i].Sort, want)
+		}
+	}
+
+	b, err = ParseOPF(b.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.TitleSort != "forty year old virgin" {
+		t.Errorf(`after rewriting, got title sort "%s"`, b.TitleSort)
+	}
+	if b.RecomputeKeys(nil) {
+		t.Error("RecomputeKeys reported a change on an updated book")
+	}
+}
+
+func TestAuthorSort(t *testing.T) {
+	cases := []struct {
+		inp, want string
+	}{{
+		inp:  "Judd Apatow",
+		want: "Apatow, Judd",
+	}, {
+		inp:  "Apatow, Judd",
+		want: "Apatow, Judd",
+	}, {
+		inp:  "J. R. R. Tolkien",
+		want: "Tolkien, J. R. R.",
+	}, {
+		inp:  "Martin Luther King Jr.",
+		want: "King, Martin Luther, Jr.",
+	}, {
+		inp:  "Prince",
+		want: "Prince",
+	}, {
+		inp:  "",
+		want: "",
+	}}
+
+	for _, tc := range cases {
+		if got := AuthorSort(tc.inp); got != tc.want {
+			t.Errorf(`AuthorSort("%s") = "%s", want "%s"`, tc.inp, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package bib
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestKeyCitation(t *testing.T) {
+	cases := []struct {
+		inp, want string
+	}{{
+		inp:  "Smith, J. (2020). The title of the work. Publisher.",
+		want: "smith j\x002020\x00title of the work",
+	}, {
+		inp:  "Smith, J., & Jones, K. (2019b, March 3). Another work.",
+		want: "smith j jones k\x002019b\x00another work",
+	}, {
+		inp:  "Smith, John. 2020. The Title of the Work. Chicago: Publisher.",
+		want: "smith john\x002020\x00title of the work",
+	}, {
+		inp:  "Smith 2020",
+		want: "smith\x002020\x00",
+	}, {
+		inp:  "Smith et al. (2020a)",
+		want: "smith et al\x002020a\x00",
+	}, {
+		inp:  "Smith, J. (n.d.). Undated.",
+		want: "smith j\x00\x00undated",
+	}, {
+		inp:  "Smith, J. (in press). Forthcoming.",
+		want: "smith j\x00~\x00forthcoming",
+	}, {
+		inp:  "The Onion",
+		want: "onion\x00\x00",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := KeyCitation(tc.inp)
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKeyCitationOrder(t *testing.T) {
+	want := []string{
+		"Smith, J. (n.d.). Undated work.",
+		"Smith, J. (2019). Zebras.",
+		"Smith, J. (2020a). Apples.",
+		"Smith, J. (2020b). Bananas.",
+		"Smith, J. (in press). Forthcoming.",
+		"Smith, J., & Adams, B. (2018). Joint work.",
+		"Smithers, A. (2001). Later name.",
+	}
+	got := []string{want[3], want[6], want[0], want[5], want[2], want[4], want[1]}
+	sort.Slice(got, func(i, j int) bool { return KeyCitation(got[i]) < KeyCitation(got[j]) })
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+}
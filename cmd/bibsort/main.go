@@ -0,0 +1,130 @@
+// Command bibsort sorts lines of text bibliographically.
+//
+// Usage:
+//
+//	bibsort [-prefix] [FILE ...]
+//	bibsort tags [-link OUTDIR | -rename] DIR
+//	bibsort xlsx [-sheet NAME] [-col COLUMN] [-header] IN OUT
+//
+// With no subcommand,
+// bibsort reads lines from the named files
+// (or standard input if there are none)
+// and writes them to standard output in bibliographic order.
+// With -prefix it instead writes each line as it is read,
+// preceded by its sort key and a tab,
+// for sorting and joining with other Unix tools
+// (see [bib.Prefixed]).
+//
+// The tags subcommand finds the audio files in DIR
+// and orders them by the title in their embedded tags
+// (or their file names when they have no title tag),
+// ignoring leading track numbers.
+// By default it prints the files' paths in order.
+// With -link it instead populates OUTDIR with symlinks to the files,
+// named so that a plain directory listing shows them in order.
+// With -rename it renames the files in place,
+// replacing any existing track number with their position in the order.
+//
+// The xlsx subcommand sorts the rows of a worksheet in the Excel workbook IN
+// by one of its columns
+// and writes the result to OUT (which may be the same as IN).
+// The column is given by its letters,
+// or, with -header, by the text in its first row,
+// which then stays in place.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/bobg/go-generics/v4/slices"
+
+	"github.com/bobg/bib"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("bibsort: ")
+
+	var (
+		err    error
+		subcmd string
+	)
+	if len(os.Args) > 1 {
+		subcmd = os.Args[1]
+	}
+	switch subcmd {
+	case "tags":
+		err = doTags(os.Args[2:])
+	case "xlsx":
+		err = doXLSX(os.Args[2:])
+	default:
+		err = doLines(os.Args[1:])
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func doLines(args []string) error {
+	var (
+		fset   = flag.NewFlagSet("bibsort", flag.ContinueOnError)
+		prefix = fset.Bool("prefix", false, "write each line preceded by its key and a tab, without sorting")
+	)
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	args = fset.Args()
+
+	var (
+		lines []string
+		w     = bufio.NewWriter(os.Stdout)
+	)
+
+	readLines := func(r io.Reader) error {
+		sc := bufio.NewScanner(r)
+		for sc.Scan() {
+			if *prefix {
+				fmt.Fprintln(w, bib.Prefixed(sc.Text()))
+				continue
+			}
+			lines = append(lines, sc.Text())
+		}
+		return sc.Err()
+	}
+
+	if len(args) == 0 {
+		if err := readLines(os.Stdin); err != nil {
+			return fmt.Errorf("reading standard input: %w", err)
+		}
+	}
+	for _, name := range args {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		err = readLines(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", name, err)
+		}
+	}
+
+	sortBy(lines, bib.Key)
+
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+	return w.Flush()
+}
+
+// sortBy sorts strs by the keys that keyFn computes for them.
+func sortBy(strs []string, keyFn func(string) string) {
+	keys := slices.Map(strs, keyFn)
+	slices.KeyedSort(strs, sort.StringSlice(keys))
+}
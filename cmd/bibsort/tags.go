@@ -0,0 +1,139 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bobg/go-generics/v4/slices"
+
+	"github.com/bobg/bib"
+	"github.com/bobg/bib/internal/audiotag"
+)
+
+var audioExts = map[string]bool{
+	".flac": true,
+	".m4a":  true,
+	".m4b":  true,
+	".mp3":  true,
+	".mp4":  true,
+	".oga":  true,
+	".ogg":  true,
+	".opus": true,
+}
+
+func doTags(args []string) error {
+	var (
+		fset   = flag.NewFlagSet("tags", flag.ContinueOnError)
+		link   = fset.String("link", "", "create ordered symlinks in this directory")
+		rename = fset.Bool("rename", false, "rename files in place with their position in the order")
+	)
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	if fset.NArg() != 1 {
+		return fmt.Errorf("usage: bibsort tags [-link OUTDIR | -rename] DIR")
+	}
+	if *link != "" && *rename {
+		return fmt.Errorf("-link and -rename are mutually exclusive")
+	}
+
+	var paths []string
+	err := filepath.WalkDir(fset.Arg(0), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && audioExts[strings.ToLower(filepath.Ext(path))] {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	keys, err := slices.Mapx(paths, func(_ int, path string) (string, error) {
+		title, err := trackTitle(path)
+		if err != nil {
+			return "", err
+		}
+		return bib.TrackKey(title), nil
+	})
+	if err != nil {
+		return err
+	}
+	slices.KeyedSort(paths, sort.StringSlice(keys))
+
+	width := len(strconv.Itoa(len(paths)))
+	if width < 2 {
+		width = 2
+	}
+	numbered := func(i int, path string) string {
+		base := filepath.Base(path)
+		return fmt.Sprintf("%0*d %s", width, i+1, bib.TrimTrackNumber(base))
+	}
+
+	switch {
+	case *link != "":
+		if err := os.MkdirAll(*link, 0755); err != nil {
+			return err
+		}
+		for i, path := range paths {
+			abs, err := filepath.Abs(path)
+			if err != nil {
+				return err
+			}
+			if err := os.Symlink(abs, filepath.Join(*link, numbered(i, path))); err != nil {
+				return err
+			}
+		}
+
+	case *rename:
+		for i, path := range paths {
+			newpath := filepath.Join(filepath.Dir(path), numbered(i, path))
+			if newpath == path {
+				continue
+			}
+			if _, err := os.Lstat(newpath); err == nil {
+				return fmt.Errorf("not renaming %s: %s already exists", path, newpath)
+			}
+			if err := os.Rename(path, newpath); err != nil {
+				return err
+			}
+		}
+
+	default:
+		for _, path := range paths {
+			fmt.Println(path)
+		}
+	}
+
+	return nil
+}
+
+// trackTitle returns the title tag of the audio file at path,
+// falling back to its file name (without extension) if it has none
+// or its tags can't be read.
+func trackTitle(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	title, err := audiotag.Title(f)
+	if err != nil {
+		log.Printf("warning: reading tags of %s: %s", path, err)
+	}
+	if title == "" {
+		base := filepath.Base(path)
+		title = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	return title, nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bobg/bib/xlsx"
+)
+
+func doXLSX(args []string) error {
+	var (
+		fset   = flag.NewFlagSet("xlsx", flag.ContinueOnError)
+		sheet  = fset.String("sheet", "", "name of the worksheet to sort (default first)")
+		col    = fset.String("col", "", "column letters, or header text with -header (default A)")
+		header = fset.Bool("header", false, "keep the first row in place as a header")
+	)
+	if err := fset.Parse(args); err != nil {
+		return err
+	}
+	if fset.NArg() != 2 {
+		return fmt.Errorf("usage: bibsort xlsx [-sheet NAME] [-col COLUMN] [-header] IN OUT")
+	}
+	in, out := fset.Arg(0), fset.Arg(1)
+
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+	opts := xlsx.Options{Sheet: *sheet, Column: *col, Header: *header}
+	if err := xlsx.Sort(bytes.NewReader(data), int64(len(data)), buf, opts); err != nil {
+		return fmt.Errorf("sorting %s: %w", in, err)
+	}
+	return os.WriteFile(out, buf.Bytes(), 0644)
+}
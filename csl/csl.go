@@ -0,0 +1,186 @@
+// Package csl sorts CSL-JSON bibliographies
+// (the format exported by Zotero and consumed by citeproc processors)
+// using bibliographic sort keys.
+package csl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/bobg/go-generics/v4/slices"
+
+	"github.com/bobg/bib"
+)
+
+// Item is a single CSL-JSON item.
+// It is kept as a generic map so that fields this package does not interpret
+// survive a round trip through [Read] and [Write].
+type Item map[string]any
+
+// Name is a CSL name variable, such as one element of an item's "author" list.
+type Name struct {
+	Family              string `json:"family,omitempty"`
+	Given               string `json:"given,omitempty"`
+	Suffix              string `json:"suffix,omitempty"`
+	DroppingParticle    string `json:"dropping-particle,omitempty"`
+	NonDroppingParticle string `json:"non-dropping-particle,omitempty"`
+	Literal             string `json:"literal,omitempty"`
+}
+
+// Title returns the item's "title" field.
+func (it Item) Title() string {
+	s, _ := it["title"].(string)
+	return s
+}
+
+// Names returns the names in the given name variable of the item,
+// e.g. "author" or "editor".
+func (it Item) Names(variable string) []Name {
+	v, ok := it[variable]
+	if !ok {
+		return nil
+	}
+	// Round-trip through JSON rather than picking apart the map by hand.
+	j, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var names []Name
+	if err := json.Unmarshal(j, &names); err != nil {
+		return nil
+	}
+	return names
+}
+
+// Issued returns the year, month, and day of the item's "issued" date.
+// Month and day are zero when absent.
+// The boolean result is false if the item has no parseable issued date.
+func (it Item) Issued() (year, month, day int, ok bool) {
+	v, found := it["issued"]
+	if !found {
+		return 0, 0, 0, false
+	}
+	j, err := json.Marshal(v)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	var d struct {
+		DateParts [][]json.Number `json:"date-parts"`
+	}
+	if err := json.Unmarshal(j, &d); err != nil || len(d.DateParts) == 0 || len(d.DateParts[0]) == 0 {
+		return 0, 0, 0, false
+	}
+
+	var parts [3]int
+	for i, p := range d.DateParts[0] {
+		if i >= len(parts) {
+			break
+		}
+		n, err := p.Int64()
+		if err != nil {
+			return 0, 0, 0, false
+		}
+		parts[i] = int(n)
+	}
+	return parts[0], parts[1], parts[2], true
+}
+
+// Order selects the sort order for [Sort].
+type Order int
+
+const (
+	// ByTitle sorts items by the bibliographic key of their titles.
+	ByTitle Order = iota
+
+	// ByAuthorDate sorts items by author names,
+	// then by issued date,
+	// then by title.
+	// Items with no authors are sorted by editor names instead,
+	// and failing that by title alone.
+	// Items with no issued date sort after dated items by the same authors.
+	ByAuthorDate
+)
+
+// Sort sorts CSL-JSON items in the given order.
+func Sort(items []Item, order Order) {
+	keyFn := TitleKey
+	if order == ByAuthorDate {
+		keyFn = AuthorDateKey
+	}
+	keys := slices.Map(items, keyFn)
+	slices.KeyedSort(items, sort.StringSlice(keys))
+}
+
+// TitleKey returns the sort key for an item's title.
+func TitleKey(it Item) string {
+	return bib.Key(it.Title())
+}
+
+// AuthorDateKey returns the sort key used by [ByAuthorDate].
+func AuthorDateKey(it Item) string {
+	names := it.Names("author")
+	if len(names) == 0 {
+		names = it.Names("editor")
+	}
+
+	var nameKeys []string
+	for _, n := range names {
+		nameKeys = append(nameKeys, nameKey(n))
+	}
+
+	// The separators are chosen to sort below any character that can appear in a key,
+	// so that a shorter list of names sorts before a longer list it is a prefix of,
+	// and so on.
+	dateKey := "~"
+	if y, m, d, ok := it.Issued(); ok {
+		dateKey = fmt.Sprintf("%04d%02d%02d", y, m, d)
+	}
+	return strings.Join(nameKeys, "\x01") + "\x00" + dateKey + "\x00" + TitleKey(it)
+}
+
+// nameKey files a name by family name, then given name.
+// Per the CSL default ("display-and-sort"),
+// particles are not part of the family name for sorting purposes.
+func nameKey(n Name) string {
+	if n.Literal != "" {
+		return bib.Key(n.Literal)
+	}
+	return bib.Key(strings.Join([]string{n.Family, n.Given, n.Suffix}, " "))
+}
+
+// Read reads a CSL-JSON array of items.
+func Read(r io.Reader) ([]Item, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var items []Item
+	if err := dec.Decode(&items); err != nil {
+		return nil, fmt.Errorf("decoding CSL-JSON: %w", err)
+	}
+	return items, nil
+}
+
+// Write writes items as a CSL-JSON array.
+func Write(w io.Writer, items []Item) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(items); err != nil {
+		return fmt.Errorf("encoding CSL-JSON: %w", err)
+	}
+	return nil
+}
+
+// SortJSON reads a CSL-JSON array from r,
+// sorts it in the given order,
+// and writes the result to w.
+func SortJSON(r io.Reader, w io.Writer, order Order) error {
+	items, err := Read(r)
+	if err != nil {
+		return err
+	}
+	Sort(items, order)
+	return Write(w, items)
+}
@@ -0,0 +1,67 @@
+package csl
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testItems = `[
+  {"id": "a", "type": "book", "title": "The 40-Year-Old Virgin", "author": [{"family": "Apatow", "given": "Judd"}], "issued": {"date-parts": [[2005, 8]]}},
+  {"id": "b", "type": "book", "title": "A Zoo in Winter", "author": [{"family": "Apatow", "given": "Judd"}], "issued": {"date-parts": [["1999"]]}},
+  {"id": "c", "type": "book", "title": "Gumball Rally", "editor": [{"family": "Bach", "given": "Chuck"}]},
+  {"id": "d", "type": "article-journal", "title": "42nd Street", "author": [{"literal": "Anonymous"}], "issued": {"date-parts": [[1933, 3, 9]]}},
+  {"id": "e", "type": "book", "author": [{"family": "Apatow", "given": "Judd"}]}
+]`
+
+func TestSort(t *testing.T) {
+	cases := []struct {
+		order Order
+		want  []string
+	}{{
+		order: ByTitle,
+		want:  []string{"e", "a", "d", "c", "b"},
+	}, {
+		order: ByAuthorDate,
+		want:  []string{"d", "b", "a", "e", "c"},
+	}}
+
+	for _, tc := range cases {
+		items, err := Read(strings.NewReader(testItems))
+		if err != nil {
+			t.Fatal(err)
+		}
+		Sort(items, tc.order)
+
+		var got []string
+		for _, it := range items {
+			got = append(got, it["id"].(string))
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("order %d: got %v, want %v", tc.order, got, tc.want)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := SortJSON(strings.NewReader(testItems), buf, ByTitle); err != nil {
+		t.Fatal(err)
+	}
+	items, err := Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 5 {
+		t.Fatalf("got %d items, want 5", len(items))
+	}
+
+	y, m, d, ok := items[1].Issued()
+	if !ok || y != 2005 || m != 8 || d != 0 {
+		t.Errorf("got issued %d-%d-%d (ok %v), want 2005-8-0", y, m, d, ok)
+	}
+	if got := items[1]["type"]; got != "book" {
+		t.Errorf(`got type %v, want "book"`, got)
+	}
+}
@@ -0,0 +1,178 @@
+// Package csvsort sorts the rows of CSV files bibliographically,
+// with presets for the reading-list exports of Goodreads and StoryGraph.
+package csvsort
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/bobg/bib"
+)
+
+// Preset describes the columns of a well-known CSV layout.
+type Preset struct {
+	// Name identifies the preset, e.g. on a command line.
+	Name string
+
+	// Title is the header of the title column.
+	Title string
+
+	// Author is the header of the author column.
+	// Names in it are in natural order ("Judd Apatow"),
+	// and there may be several, separated by commas.
+	Author string
+
+	// AuthorSort, if not empty, is the header of a column
+	// giving the (first) author's name in inverted order ("Apatow, Judd").
+	// It is preferred to Author when sorting by author.
+	AuthorSort string
+}
+
+var (
+	// Goodreads is the layout of the Goodreads "Export Library" CSV.
+	Goodreads = Preset{Name: "goodreads", Title: "Title", Author: "Author", AuthorSort: "Author l-f"}
+
+	// StoryGraph is the layout of the StoryGraph "Export StoryGraph Library" CSV.
+	StoryGraph = Preset{Name: "storygraph", Title: "Title", Author: "Authors"}
+
+	// Presets are the known presets, in the order [Detect] tries them.
+	Presets = []Preset{Goodreads, StoryGraph}
+)
+
+// Detect returns the preset matching a CSV header row, if any.
+func Detect(header []string) (Preset, bool) {
+	for _, p := range Presets {
+		if p.matches(header) {
+			return p, true
+		}
+	}
+	return Preset{}, false
+}
+
+func (p Preset) matches(header []string) bool {
+	for _, col := range []string{p.Title, p.Author, p.AuthorSort} {
+		if col != "" && index(header, col) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func index(header []string, col string) int {
+	for i, h := range header {
+		if strings.TrimSpace(h) == col {
+			return i
+		}
+	}
+	return -1
+}
+
+// By selects the primary sort field.
+type By int
+
+const (
+	// ByTitle sorts rows by title, then by author.
+	ByTitle By = iota
+
+	// ByAuthor sorts rows by author, then by title.
+	ByAuthor
+)
+
+// Sort reads CSV with a header row from r,
+// sorts its rows according to the preset's columns,
+// and writes the result to w.
+// Rows whose keys are equal keep their original relative order.
+//
+// The output is cleaned along the way:
+// leading and trailing whitespace is trimmed from every field,
+// and spreadsheet-formula wrappers like Goodreads's ="0618260307" around ISBNs are removed.
+func Sort(r io.Reader, w io.Writer, p Preset, by By) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return fmt.Errorf("reading CSV: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	for _, row := range rows {
+		for i, field := range row {
+			row[i] = clean(field)
+		}
+	}
+
+	header, rows := rows[0], rows[1:]
+
+	titleCol := index(header, p.Title)
+	if titleCol < 0 {
+		return fmt.Errorf("no %s column", p.Title)
+	}
+	authorCol := index(header, p.Author)
+	if authorCol < 0 {
+		return fmt.Errorf("no %s column", p.Author)
+	}
+	authorSortCol := -1
+	if p.AuthorSort != "" {
+		authorSortCol = index(header, p.AuthorSort)
+	}
+
+	type keyedRow struct {
+		key string
+		row []string
+	}
+	keyed := make([]keyedRow, 0, len(rows))
+	for _, row := range rows {
+		titleKey := bib.Key(get(row, titleCol))
+
+		authorKey := bib.PersonKey(get(row, authorSortCol))
+		if authorKey == "" {
+			authorKey = bib.PersonKey(firstAuthor(get(row, authorCol)))
+		}
+
+		key := titleKey + "\x00" + authorKey
+		if by == ByAuthor {
+			key = authorKey + "\x00" + titleKey
+		}
+		keyed = append(keyed, keyedRow{key: key, row: row})
+	}
+	sort.SliceStable(keyed, func(i, j int) bool { return keyed[i].key < keyed[j].key })
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("writing CSV: %w", err)
+	}
+	for _, k := range keyed {
+		if err := cw.Write(k.row); err != nil {
+			return fmt.Errorf("writing CSV: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func get(row []string, col int) string {
+	if col < 0 || col >= len(row) {
+		return ""
+	}
+	return row[col]
+}
+
+func clean(field string) string {
+	field = strings.TrimSpace(field)
+	if strings.HasPrefix(field, `="`) && strings.HasSuffix(field, `"`) && len(field) >= 3 {
+		field = field[2 : len(field)-1]
+	}
+	return field
+}
+
+func firstAuthor(s string) string {
+	if i := strings.IndexByte(s, ','); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,73 @@
+package csvsort
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const goodreadsCSV = `Book Id,Title,Author,Author l-f,Additional Authors,ISBN,ISBN13,My Rating
+1,The Gumball Rally,Chuck Bail,"Bail, Chuck",,"=""0618260307""","=""9780618260300""",4
+2,42nd Street,Bradford Ropes,"Ropes, Bradford",,"=""""","=""""",5
+3,  An Apple a Day ,Judd Apatow,"Apatow, Judd",,"=""""","=""""",3
+`
+
+const storyGraphCSV = `Title,Authors,Contributors,ISBN/UID,Format
+The Gumball Rally,Chuck Bail,,9780618260300,paperback
+42nd Street,Bradford Ropes,,,hardcover
+An Apple a Day,"Judd Apatow, Seth Rogen",,,digital
+`
+
+func TestSort(t *testing.T) {
+	cases := []struct {
+		name string
+		inp  string
+		by   By
+		want string
+	}{{
+		name: "goodreads by title",
+		inp:  goodreadsCSV,
+		by:   ByTitle,
+		want: `Book Id,Title,Author,Author l-f,Additional Authors,ISBN,ISBN13,My Rating
+3,An Apple a Day,Judd Apatow,"Apatow, Judd",,,,3
+2,42nd Street,Bradford Ropes,"Ropes, Bradford",,,,5
+1,The Gumball Rally,Chuck Bail,"Bail, Chuck",,0618260307,9780618260300,4
+`,
+	}, {
+		name: "goodreads by author",
+		inp:  goodreadsCSV,
+		by:   ByAuthor,
+		want: `Book Id,Title,Author,Author l-f,Additional Authors,ISBN,ISBN13,My Rating
+3,An Apple a Day,Judd Apatow,"Apatow, Judd",,,,3
+1,The Gumball Rally,Chuck Bail,"Bail, Chuck",,0618260307,9780618260300,4
+2,42nd Street,Bradford Ropes,"Ropes, Bradford",,,,5
+`,
+	}, {
+		name: "storygraph by author",
+		inp:  storyGraphCSV,
+		by:   ByAuthor,
+		want: `Title,Authors,Contributors,ISBN/UID,Format
+An Apple a Day,"Judd Apatow, Seth Rogen",,,digital
+The Gumball Rally,Chuck Bail,,9780618260300,paperback
+42nd Street,Bradford Ropes,,,hardcover
+`,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := strings.Split(strings.SplitN(tc.inp, "\n", 2)[0], ",")
+			p, ok := Detect(header)
+			if !ok {
+				t.Fatal("no preset detected")
+			}
+
+			buf := new(bytes.Buffer)
+			if err := Sort(strings.NewReader(tc.inp), buf, p, tc.by); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package bib
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// WithDimensions makes a [Keyer] recognize dimensions,
+// such as "4x4" and "2×4,"
+// and spell them out as "four by four" and "two by four."
+// Some dimensional designations such as "3-D" and "2D"
+// are likewise spelled out as "three d" and "two d."
+// Without this option,
+// "4x4" keys as written,
+// and "2×4" runs together as "twenty-four."
+func WithDimensions() Option {
+	return func(c *config) {
+		c.dimensions = true
+	}
+}
+
+var (
+	dimensionRegex = regexp.MustCompile(`^["'(\[]*(\d+(?:[x×]\d+)+)["')\],;:!?.]*$`)
+	dRegex         = regexp.MustCompile(`^["'(\[]*(\d+)-?d["')\],;:!?.]*$`)
+)
+
+// dimension tells whether the lowercase chunk is a dimension
+// and if so returns it in words.
+func (k *Keyer) dimension(chunk string) ([]string, bool) {
+	if m := dRegex.FindStringSubmatch(chunk); m != nil {
+		n, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		return append(k.intToWords(n, false), "d"), true
+	}
+
+	m := dimensionRegex.FindStringSubmatch(chunk)
+	if m == nil {
+		return nil, false
+	}
+	var words []string
+	for i, num := range strings.FieldsFunc(m[1], func(r rune) bool { return r == 'x' || r == '×' }) {
+		n, err := strconv.ParseInt(num, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		if i > 0 {
+			words = append(words, "by")
+		}
+		words = append(words, k.intToWords(n, false)...)
+	}
+	return words, true
+}
@@ -0,0 +1,51 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDimensions(t *testing.T) {
+	cases := []struct {
+		inp, want string
+	}{{
+		inp:  "4x4 Trucks",
+		want: "four by four trucks",
+	}, {
+		inp:  "The 2×4 Guide",
+		want: "two by four guide",
+	}, {
+		inp:  "Building with 2x4x8s",
+		want: "building with 2x4x8s",
+	}, {
+		inp:  "Building with 2x4x8",
+		want: "building with two by four by eight",
+	}, {
+		inp:  "3-D Chess",
+		want: "three d chess",
+	}, {
+		inp:  "(2D)",
+		want: "two d",
+	}, {
+		inp:  "Boxes",
+		want: "boxes",
+	}, {
+		inp:  "0x1F",
+		want: "0x1f",
+	}}
+
+	k := NewKeyer(WithDimensions())
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := k.Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+
+	// Without the option, dimensions key as written.
+	if got := Key("4x4 Trucks"); got != "4x4 trucks" {
+		t.Errorf(`got "%s" without WithDimensions, want "4x4 trucks"`, got)
+	}
+}
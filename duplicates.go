@@ -0,0 +1,180 @@
+package bib
+
+import (
+	"sort"
+)
+
+// DuplicateOptions controls [FindDuplicates].
+type DuplicateOptions struct {
+	// MaxDistance is the largest edit distance
+	// (the number of single-character insertions, deletions, and substitutions)
+	// between two keys for their entries to be considered duplicates.
+	// Zero means only entries with equal keys are duplicates.
+	// Since the distance is absolute,
+	// values above 1 or 2 produce many false matches among short keys.
+	MaxDistance int
+
+	// Keyer produces the keys that are compared.
+	// If this is nil, [Default] is used.
+	Keyer *Keyer
+}
+
+// Cluster is a group of entries found by [FindDuplicates].
+type Cluster struct {
+	// Key is the cluster's most common key
+	// (the lexically first, if there is a tie).
+	Key string
+
+	// Entries are the cluster's members, in corpus order.
+	Entries []Duplicate
+}
+
+// Duplicate is one member of a [Cluster].
+type Duplicate struct {
+	// Index is the entry's position in the corpus.
+	Index int
+
+	// Text is the entry as it appears in the corpus.
+	Text string
+
+	// Key is the entry's key.
+	// It differs from the cluster's key only if
+	// [DuplicateOptions.MaxDistance] is greater than zero.
+	Key string
+}
+
+// FindDuplicates finds clusters of entries in corpus
+// whose keys are equal,
+// or, with a nonzero [DuplicateOptions.MaxDistance],
+// nearly so.
+// Near-duplication is transitive:
+// if A is near B and B is near C,
+// all three are in one cluster even if A is not near C.
+// Entries with nothing to file on, such as empty strings and "!!!", are ignored.
+//
+// With a zero MaxDistance,
+// the clusters are the collisions among the keys of corpus:
+// groups of entries that [Sort] cannot tell apart,
+// like "Its" and "It's,"
+// which a cataloger may want to review
+// (e.g. to add aliases with [WithAliases]).
+//
+// The clusters are in order of their keys.
+// Only clusters with at least two entries are returned.
+//
+// Comparing near-duplicates takes time proportional to the square of
+// the number of distinct keys of similar length.
+func FindDuplicates(corpus []string, opts DuplicateOptions) []Cluster {
+	k := opts.Keyer
+	if k == nil {
+		k = Default()
+	}
+
+	byKey := make(map[string][]Duplicate)
+	for i, s := range corpus {
+		key, info := k.key(s)
+		if info.unfiled {
+			continue
+		}
+		byKey[key] = append(byKey[key], Duplicate{Index: i, Text: s, Key: key})
+	}
+
+	keys := make([]string, 0, len(byKey))
+	for key := range byKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Union-find over the distinct keys.
+	parent := make([]int, len(keys))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	if opts.MaxDistance > 0 {
+		runes := make([][]rune, len(keys))
+		for i, key := range keys {
+			runes[i] = []rune(key)
+		}
+		for i := range keys {
+			for j := i + 1; j < len(keys); j++ {
+				if abs(len(runes[i])-len(runes[j])) > opts.MaxDistance {
+					continue
+				}
+				if editDistance(runes[i], runes[j], opts.MaxDistance) <= opts.MaxDistance {
+					parent[find(j)] = find(i)
+				}
+			}
+		}
+	}
+
+	members := make(map[int][]int) // root -> key indexes
+	for i := range keys {
+		root := find(i)
+		members[root] = append(members[root], i)
+	}
+
+	var result []Cluster
+	for _, idxs := range members {
+		var (
+			c    Cluster
+			best int
+		)
+		for _, i := range idxs {
+			dups := byKey[keys[i]]
+			c.Entries = append(c.Entries, dups...)
+			if len(dups) > best {
+				// idxs is in key order, so the first of the most common wins a tie.
+				c.Key, best = keys[i], len(dups)
+			}
+		}
+		if len(c.Entries) < 2 {
+			continue
+		}
+		sort.Slice(c.Entries, func(i, j int) bool { return c.Entries[i].Index < c.Entries[j].Index })
+		result = append(result, c)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+// editDistance computes the Levenshtein distance between a and b,
+// or some number greater than limit if it exceeds limit.
+func editDistance(a, b []rune, limit int) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > limit {
+			return rowMin
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,103 @@
+package bib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	corpus := []string{
+		"The Gumball Rally",   // 0
+		"Pride and Prejudice", // 1
+		"Gumball Rally",       // 2
+		"Pride & Prejudice",   // 3
+		"Pride and Prejudise", // 4
+		"Emma",                // 5
+		"",                    // 6
+		"gumball rally!",      // 7
+		"---",                 // 8
+	}
+
+	t.Run("exact", func(t *testing.T) {
+		got := FindDuplicates(corpus, DuplicateOptions{})
+		want := []Cluster{{
+			Key: "gumball rally",
+			Entries: []Duplicate{
+				{Index: 0, Text: "The Gumball Rally", Key: "gumball rally"},
+				{Index: 2, Text: "Gumball Rally", Key: "gumball rally"},
+				{Index: 7, Text: "gumball rally!", Key: "gumball rally"},
+			},
+		}, {
+			Key: "pride and prejudice",
+			Entries: []Duplicate{
+				{Index: 1, Text: "Pride and Prejudice", Key: "pride and prejudice"},
+				{Index: 3, Text: "Pride & Prejudice", Key: "pride and prejudice"},
+			},
+		}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("near", func(t *testing.T) {
+		got := FindDuplicates(corpus, DuplicateOptions{MaxDistance: 1})
+		if len(got) != 2 {
+			t.Fatalf("got %d clusters, want 2", len(got))
+		}
+		c := got[1]
+		if c.Key != "pride and prejudice" {
+			t.Errorf(`got key "%s", want "pride and prejudice"`, c.Key)
+		}
+		var idxs []int
+		for _, e := range c.Entries {
+			idxs = append(idxs, e.Index)
+		}
+		if want := []int{1, 3, 4}; !reflect.DeepEqual(idxs, want) {
+			t.Errorf("got indexes %v, want %v", idxs, want)
+		}
+		if got := c.Entries[2].Key; got != "pride and prejudise" {
+			t.Errorf(`got member key "%s", want "pride and prejudise"`, got)
+		}
+	})
+}
+
+func TestEditDistance(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "abc", 0},
+		{"abc", "abd", 1},
+		{"abc", "ab", 1},
+		{"kitten", "sitting", 3},
+		{"żółw", "zółw", 1},
+	}
+	for _, tc := range cases {
+		if got := editDistance([]rune(tc.a), []rune(tc.b), 10); got != tc.want {
+			t.Errorf(`editDistance("%s", "%s") = %d, want %d`, tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestKeyCollisions(t *testing.T) {
+	corpus := []string{"Its", "Emma", "It's", "ITS!", "Emma", "It’s a Wonderful Life"}
+	got := FindDuplicates(corpus, DuplicateOptions{})
+	want := []Cluster{{
+		Key: "emma",
+		Entries: []Duplicate{
+			{Index: 1, Text: "Emma", Key: "emma"},
+			{Index: 4, Text: "Emma", Key: "emma"},
+		},
+	}, {
+		Key: "its",
+		Entries: []Duplicate{
+			{Index: 0, Text: "Its", Key: "its"},
+			{Index: 2, Text: "It's", Key: "its"},
+			{Index: 3, Text: "ITS!", Key: "its"},
+		},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
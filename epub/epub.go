@@ -0,0 +1,145 @@
+// Package epub reads EPUB package metadata
+// and sorts books bibliographically by title.
+package epub
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/bobg/go-generics/v4/slices"
+
+	"github.com/bobg/bib"
+)
+
+// Book is the metadata of an EPUB publication
+// that is relevant to sorting.
+type Book struct {
+	// Path is the file the book was read from, if any.
+	Path string
+
+	// Title is the first dc:title in the package document,
+	// which EPUB designates as the primary title.
+	Title string
+
+	// Creators are the dc:creator values in the package document.
+	Creators []string
+}
+
+// SortTitle returns the bibliographic sort key for the book's title.
+func (b *Book) SortTitle() string {
+	return bib.Key(b.Title)
+}
+
+// FileAs returns the form of the book's title
+// for the EPUB 3 file-as property of its dc:title,
+// with a leading article moved to the end
+// ("Gumball Rally, The")
+// and the title's case and punctuation otherwise kept.
+// See [bib.DisplayForm].
+func (b *Book) FileAs() string {
+	return bib.DisplayForm(b.Title)
+}
+
+// Sort sorts books bibliographically by title.
+func Sort(books []*Book) {
+	keys := slices.Map(books, (*Book).SortTitle)
+	slices.KeyedSort(books, sort.StringSlice(keys))
+}
+
+type opfPackage struct {
+	Metadata struct {
+		Titles   []string `xml:"http://purl.org/dc/elements/1.1/ title"`
+		Creators []string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	} `xml:"metadata"`
+}
+
+// ReadOPF reads an EPUB package document (the .opf file).
+func ReadOPF(r io.Reader) (*Book, error) {
+	var pkg opfPackage
+	if err := xml.NewDecoder(r).Decode(&pkg); err != nil {
+		return nil, fmt.Errorf("decoding package document: %w", err)
+	}
+
+	b := &Book{Creators: trimAll(pkg.Metadata.Creators)}
+	if len(pkg.Metadata.Titles) > 0 {
+		b.Title = strings.TrimSpace(pkg.Metadata.Titles[0])
+	}
+	return b, nil
+}
+
+func trimAll(strs []string) []string {
+	return slices.Map(strs, strings.TrimSpace)
+}
+
+type container struct {
+	Rootfiles []struct {
+		FullPath  string `xml:"full-path,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// Read reads the metadata of the EPUB file in r,
+// which has the given size.
+// It locates the package document via META-INF/container.xml.
+func Read(r io.ReaderAt, size int64) (*Book, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("opening EPUB archive: %w", err)
+	}
+
+	cf, err := zr.Open("META-INF/container.xml")
+	if err != nil {
+		return nil, fmt.Errorf("opening container: %w", err)
+	}
+	defer cf.Close()
+
+	var c container
+	if err := xml.NewDecoder(cf).Decode(&c); err != nil {
+		return nil, fmt.Errorf("decoding container: %w", err)
+	}
+
+	var opfPath string
+	for _, rf := range c.Rootfiles {
+		if rf.MediaType == "" || rf.MediaType == "application/oebps-package+xml" {
+			opfPath = rf.FullPath
+			break
+		}
+	}
+	if opfPath == "" {
+		return nil, fmt.Errorf("no package document in container")
+	}
+
+	of, err := zr.Open(opfPath)
+	if err != nil {
+		return nil, fmt.Errorf("opening package document %s: %w", opfPath, err)
+	}
+	defer of.Close()
+
+	return ReadOPF(of)
+}
+
+// Open reads the metadata of the EPUB file at the given path.
+func Open(path string) (*Book, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("statting %s: %w", path, err)
+	}
+
+	b, err := Read(f, info.Size())
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	b.Path = path
+	return b, nil
+}
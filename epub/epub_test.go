@@ -0,0 +1,76 @@
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testOPF = `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="uid">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="uid">urn:uuid:12345</dc:identifier>
+    <dc:title id="t1">The 40-Year-Old Virgin</dc:title>
+    <dc:title id="t2">A Novelization</dc:title>
+    <dc:creator>Judd Apatow</dc:creator>
+  </metadata>
+</package>`
+
+func TestRead(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for name, content := range map[string]string{
+		"mimetype":               "application/epub+zip",
+		"META-INF/container.xml": `<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container"><rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles></container>`,
+		"OEBPS/content.opf":      testOPF,
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Book{Title: "The 40-Year-Old Virgin", Creators: []string{"Judd Apatow"}}
+	if !reflect.DeepEqual(b, want) {
+		t.Errorf("got %+v, want %+v", b, want)
+	}
+	if got := b.SortTitle(); got != "forty year old virgin" {
+		t.Errorf(`got sort title "%s", want "forty year old virgin"`, got)
+	}
+	if got := b.FileAs(); got != "40-Year-Old Virgin, The" {
+		t.Errorf(`got file-as "%s", want "40-Year-Old Virgin, The"`, got)
+	}
+}
+
+func TestSort(t *testing.T) {
+	var books []*Book
+	for _, title := range []string{"The Gumball Rally", "42nd Street", "", "An Apple"} {
+		b, err := ReadOPF(strings.NewReader(strings.Replace(testOPF, "The 40-Year-Old Virgin", title, 1)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		books = append(books, b)
+	}
+	Sort(books)
+
+	var got []string
+	for _, b := range books {
+		got = append(got, b.Title)
+	}
+	want := []string{"", "An Apple", "42nd Street", "The Gumball Rally"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,88 @@
+package bib
+
+import (
+	"strconv"
+)
+
+// WithEras makes a [Keyer] using [NumbersByValue] or [NumbersAllByValue]
+// file a leading year marked "BC" or "BCE" before all other numbers,
+// as a negative number,
+// so that "44 BC" precedes "AD 1066,"
+// and "753 BC" precedes "44 BC."
+// Without it, "44 BC" files by value as 44.
+//
+// Whether or not this option is given,
+// a leading year is filed with its era marker after it,
+// so "AD 1066" files like "1066 AD,"
+// and the year is spelled as a year ("ten sixty-six ad").
+func WithEras() Option {
+	return func(c *config) {
+		c.eras = true
+	}
+}
+
+// eraWords are the era markers that may follow a year.
+// Only "AD" may also precede one.
+var eraWords = map[string]bool{
+	"ad":  true,
+	"bc":  true,
+	"bce": true,
+	"ce":  true,
+}
+
+// era converts a year and an era marker at the start of toks,
+// as in "44 BC" and "AD 1066,"
+// into the year followed by the marker,
+// according to k's [NumberMode].
+// The year and the marker must each be a chunk of the input by itself.
+// It reports false if toks does not start with such a pair.
+// If nums is not nil,
+// era appends the year to it (see [NumberSpans]).
+func (k *Keyer) era(toks []token, nums *[]NumberSpan) ([]string, bool) {
+	if len(toks) < 2 || toks[1].start == toks[0].start {
+		return nil, false
+	}
+	if len(toks) > 2 && toks[2].start == toks[1].start {
+		return nil, false
+	}
+
+	var digits, marker string
+	switch {
+	case allDigits(toks[0].text) && eraWords[toks[1].text]:
+		digits, marker = toks[0].text, toks[1].text
+	case toks[0].text == "ad" && allDigits(toks[1].text):
+		digits, marker = toks[1].text, toks[0].text
+	default:
+		return nil, false
+	}
+
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if k.byValue() {
+		addNumbers(nums, NumberSpan{Value: n})
+		if k.cfg.eras && (marker == "bc" || marker == "bce") {
+			return []string{k.encodeNegative(digits), marker}, true
+		}
+		return []string{k.encodeInt(digits), marker}, true
+	}
+	if err != nil {
+		return nil, false
+	}
+	addNumbers(nums, NumberSpan{Value: n})
+	return append(k.spellEraYear(n), marker), true
+}
+
+// spellEraYear spells n, the year in an era-marked pair,
+// with the year reading ("ten sixty-six")
+// whatever k's year ranges and [WithoutYears]:
+// the era marker is the clearest sign that n is a year.
+// The first ten years of each thousand keep the plain reading
+// ("two thousand five"),
+// as do numbers that can't be spelled as years at all.
+func (k *Keyer) spellEraYear(n int64) []string {
+	if n%1000 < 10 {
+		return k.spellInt(n, false, false)
+	}
+	sp := k.spelling(true)
+	sp.yearRanges = []YearRange{{From: n, To: n}}
+	return k.spellWith(n, false, sp)
+}
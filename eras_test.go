@@ -0,0 +1,74 @@
+package bib
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestEras(t *testing.T) {
+	cases := []struct {
+		opts      []Option
+		inp, want string
+	}{{
+		inp:  "44 BC",
+		want: "forty-four bc",
+	}, {
+		inp:  "AD 1066",
+		want: "ten sixty-six ad",
+	}, {
+		inp:  "1066 A.D.",
+		want: "ten sixty-six ad",
+	}, {
+		opts: []Option{WithoutYears()},
+		inp:  "AD 1066",
+		want: "ten sixty-six ad",
+	}, {
+		opts: []Option{WithYearRanges(YearRange{From: 1900, To: 1999})},
+		inp:  "1215 AD",
+		want: "twelve fifteen ad",
+	}, {
+		opts: []Option{WithOhYears()},
+		inp:  "1005 AD",
+		want: "one thousand five ad",
+	}, {
+		inp:  "1215 AD",
+		want: "twelve fifteen ad",
+	}, {
+		inp:  "300 BCE Warriors",
+		want: "three hundred bce warriors",
+	}, {
+		inp:  "Ad Astra",
+		want: "ad astra",
+	}, {
+		opts: []Option{WithNumberMode(NumbersByValue)},
+		inp:  "44 BC",
+		want: "0244 bc",
+	}, {
+		opts: []Option{WithNumberMode(NumbersByValue), WithEras()},
+		inp:  "44 BC",
+		want: "-9755 bc",
+	}, {
+		opts: []Option{WithNumberMode(NumbersAsWritten)},
+		inp:  "AD 1066",
+		want: "ad 1066",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(tc.opts...).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestErasOrder(t *testing.T) {
+	x := []string{"AD 1066", "44 BC", "9 to 5", "753 BC", "1066 and All That"}
+	SortWith(x, WithNumberMode(NumbersByValue), WithEras())
+	want := []string{"753 BC", "44 BC", "9 to 5", "AD 1066", "1066 and All That"}
+	if !reflect.DeepEqual(x, want) {
+		t.Errorf("got %q, want %q", x, want)
+	}
+}
@@ -0,0 +1,219 @@
+// Package feed sorts the items of RSS and Atom feeds bibliographically by title.
+//
+// It understands RSS 2.0 (and the 0.9x versions it grew from),
+// RSS 1.0 (RDF),
+// and Atom.
+// Sorting rearranges the feed's item elements byte for byte,
+// leaving everything else in the document exactly as it was.
+// (For OPDS catalogs, which are Atom feeds, see also package opds.)
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bobg/go-generics/v4/slices"
+
+	"github.com/bobg/bib"
+	"github.com/bobg/bib/internal/xmlsplice"
+)
+
+// Format is the format of a feed.
+type Format int
+
+const (
+	RSS  Format = iota // RSS 2.0 and earlier
+	RDF                // RSS 1.0
+	Atom               // Atom 1.0
+)
+
+const (
+	atomNS = "http://www.w3.org/2005/Atom"
+	rdfNS  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+)
+
+// Feed is a parsed feed.
+type Feed struct {
+	Format Format
+
+	// Items are the feed's items (or Atom entries),
+	// in document order until [Feed.Sort] is called.
+	// Callers may reorder Items but must not add or remove any.
+	Items []*Item
+
+	src   []byte
+	slots [][2]int // byte ranges of the original items in src
+}
+
+// Item is one item in a feed.
+type Item struct {
+	// Title is the text of the item's title,
+	// with any HTML markup removed.
+	Title string
+
+	// XML is the item element, verbatim from the source document.
+	XML []byte
+}
+
+// SortTitle returns the bibliographic sort key for the item's title.
+func (it *Item) SortTitle() string {
+	return bib.Key(it.Title)
+}
+
+// Parse parses a feed.
+func Parse(data []byte) (*Feed, error) {
+	f := &Feed{src: data}
+
+	var (
+		dec = xml.NewDecoder(bytes.NewReader(data))
+
+		// itemDepth is the depth at which item elements appear:
+		// inside the channel element in RSS 2.0,
+		// and directly inside the root element in RSS 1.0 and Atom.
+		itemDepth int
+		itemName  string
+		depth     int
+	)
+	dec.Strict = false // RSS in the wild is often not well-formed
+	dec.Entity = xml.HTMLEntity
+	for {
+		start := dec.InputOffset()
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing feed: %w", err)
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				switch {
+				case tok.Name.Local == "rss":
+					f.Format, itemDepth, itemName = RSS, 2, "item"
+				case tok.Name.Local == "RDF" && tok.Name.Space == rdfNS:
+					f.Format, itemDepth, itemName = RDF, 1, "item"
+				case tok.Name.Local == "feed" && tok.Name.Space == atomNS:
+					f.Format, itemDepth, itemName = Atom, 1, "entry"
+				default:
+					return nil, fmt.Errorf("root element is %s, not a feed", tok.Name.Local)
+				}
+			}
+			if depth == itemDepth && tok.Name.Local == itemName {
+				title, err := readItem(dec, f.Format)
+				if err != nil {
+					return nil, err
+				}
+				end := int(dec.InputOffset())
+				f.Items = append(f.Items, &Item{Title: title, XML: data[start:end]})
+				f.slots = append(f.slots, [2]int{int(start), end})
+				continue
+			}
+			depth++
+
+		case xml.EndElement:
+			depth--
+		}
+	}
+
+	return f, nil
+}
+
+// readItem consumes tokens through the end of the current item element
+// and returns the text of its title.
+func readItem(dec *xml.Decoder, format Format) (string, error) {
+	var (
+		title      strings.Builder
+		isHTML     = format != Atom // RSS titles often contain escaped HTML
+		depth      = 1
+		titleDepth = 0 // nonzero while inside the title element
+		sawTitle   bool
+	)
+	for depth > 0 {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", fmt.Errorf("parsing item: %w", err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 2 && !sawTitle && tok.Name.Local == "title" && (format != Atom || tok.Name.Space == atomNS) {
+				titleDepth, sawTitle = depth, true
+				for _, attr := range tok.Attr {
+					if format == Atom && attr.Name.Local == "type" {
+						isHTML = attr.Value == "html"
+					}
+				}
+			}
+
+		case xml.EndElement:
+			if depth == titleDepth {
+				titleDepth = 0
+			}
+			depth--
+
+		case xml.CharData:
+			if titleDepth > 0 {
+				title.Write(tok)
+			}
+		}
+	}
+
+	s := title.String()
+	if isHTML {
+		s = stripHTML(s)
+	}
+	return strings.TrimSpace(s), nil
+}
+
+// stripHTML removes the tags from HTML that arrived as escaped text
+// and decodes its character references.
+func stripHTML(s string) string {
+	return html.UnescapeString(tagRegex.ReplaceAllString(s, ""))
+}
+
+var tagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// Sort sorts the feed's items bibliographically by title.
+// Items whose keys are equal keep their original relative order.
+func (f *Feed) Sort() {
+	keys := slices.Map(f.Items, (*Item).SortTitle)
+	idx := make([]int, len(f.Items))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return keys[idx[i]] < keys[idx[j]] })
+	f.Items = slices.Map(idx, func(i int) *Item { return f.Items[i] })
+}
+
+// Bytes returns the feed document
+// with its items in their current order.
+// Each item occupies the place in the document
+// where the item at the same position originally appeared.
+func (f *Feed) Bytes() []byte {
+	return xmlsplice.Splice(f.src, f.slots, slices.Map(f.Items, func(it *Item) []byte { return it.XML }))
+}
+
+// Sort reads a feed from r,
+// sorts its items bibliographically by title,
+// and writes the result to w.
+func Sort(r io.Reader, w io.Writer) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading feed: %w", err)
+	}
+	f, err := Parse(data)
+	if err != nil {
+		return err
+	}
+	f.Sort()
+	_, err = w.Write(f.Bytes())
+	return err
+}
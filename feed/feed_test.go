@@ -0,0 +1,95 @@
+package feed
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	cases := []struct {
+		name   string
+		format Format
+		inp    string
+		want   string
+	}{{
+		name:   "rss",
+		format: RSS,
+		inp: `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Archive</title>
+<item><title>The Zoo Story</title><link>z</link></item>
+<item><title>&lt;em&gt;42nd&lt;/em&gt; Street</title></item>
+<!-- comment -->
+<item><title>An Apple a Day</title></item>
+<item><description>untitled</description></item>
+</channel></rss>`,
+		want: `<?xml version="1.0"?>
+<rss version="2.0"><channel><title>Archive</title>
+<item><description>untitled</description></item>
+<item><title>An Apple a Day</title></item>
+<!-- comment -->
+<item><title>&lt;em&gt;42nd&lt;/em&gt; Street</title></item>
+<item><title>The Zoo Story</title><link>z</link></item>
+</channel></rss>`,
+	}, {
+		name:   "rdf",
+		format: RDF,
+		inp: `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/">
+<channel rdf:about="x"><title>Archive</title></channel>
+<item rdf:about="b"><title>Beta</title></item>
+<item rdf:about="a"><title>Alpha</title></item>
+</rdf:RDF>`,
+		want: `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/">
+<channel rdf:about="x"><title>Archive</title></channel>
+<item rdf:about="a"><title>Alpha</title></item>
+<item rdf:about="b"><title>Beta</title></item>
+</rdf:RDF>`,
+	}, {
+		name:   "atom",
+		format: Atom,
+		inp: `<feed xmlns="http://www.w3.org/2005/Atom"><title>Archive</title>
+<entry><title type="html">&lt;b&gt;Zed&lt;/b&gt;</title></entry>
+<entry><title>The 9 Lives</title></entry>
+</feed>`,
+		want: `<feed xmlns="http://www.w3.org/2005/Atom"><title>Archive</title>
+<entry><title>The 9 Lives</title></entry>
+<entry><title type="html">&lt;b&gt;Zed&lt;/b&gt;</title></entry>
+</feed>`,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := Parse([]byte(tc.inp))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if f.Format != tc.format {
+				t.Errorf("got format %d, want %d", f.Format, tc.format)
+			}
+
+			buf := new(bytes.Buffer)
+			if err := Sort(strings.NewReader(tc.inp), buf); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTitle(t *testing.T) {
+	f, err := Parse([]byte(`<rss><channel><item><title>&lt;em&gt;Tom &amp;amp; Jerry&lt;/em&gt;</title></item></channel></rss>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.Items[0].Title, "Tom & Jerry"; got != want {
+		t.Errorf(`got "%s", want "%s"`, got, want)
+	}
+}
+
+func TestNotAFeed(t *testing.T) {
+	if _, err := Parse([]byte(`<html></html>`)); err == nil {
+		t.Error("got no error")
+	}
+}
@@ -0,0 +1,217 @@
+package bib
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PersonKey converts a personal name to a sort key,
+// filing it under the family name.
+// The name may be inverted ("King, Martin Luther, Jr.")
+// or in natural order ("Martin Luther King Jr."),
+// in which case the last word is taken as the family name,
+// not counting suffixes like "Jr." and "III".
+// Unlike [Key], PersonKey never drops a leading article
+// or spells out numbers.
+func PersonKey(name string) string {
+	return strings.Join(words(PersonDisplayForm(name)), " ")
+}
+
+// PersonDisplayForm inverts a personal name given in natural order,
+// putting the family name first
+// as [PersonKey] files it,
+// with case and punctuation kept:
+// "Judd Apatow" is "Apatow, Judd"
+// and "Martin Luther King Jr." is "King, Martin Luther, Jr."
+// This is the conventional form for alphabetized lists of names.
+// A name that already has a comma is assumed to be inverted
+// and is returned with surrounding space removed,
+// as is a name of one word.
+func PersonDisplayForm(name string) string {
+	name = strings.TrimSpace(name)
+	if strings.Contains(name, ",") {
+		return name
+	}
+	f := strings.Fields(name)
+	last := len(f) - 1
+	for last > 0 && isNameSuffix(f[last]) {
+		last--
+	}
+	if last <= 0 {
+		return strings.Join(f, " ")
+	}
+	result := f[last] + ", " + strings.Join(f[:last], " ")
+	if suffixes := f[last+1:]; len(suffixes) > 0 {
+		result += ", " + strings.Join(suffixes, " ")
+	}
+	return result
+}
+
+func isNameSuffix(word string) bool {
+	switch strings.ToLower(strings.TrimSuffix(word, ".")) {
+	case "jr", "sr", "ii", "iii", "iv", "phd", "md", "esq":
+		return true
+	}
+	return false
+}
+
+// DateKey converts a date to a sort key
+// that orders dates chronologically.
+// It understands ISO 8601 dates ("2020-01-02", "2020-01", "2020"),
+// dates with month names ("January 2, 2020", "2 Jan 2020"),
+// and catalogers' approximations ("c1999", "[1999?]", "ca. 1850"),
+// filing a date range under its start.
+// Missing month and day sort before any month and day.
+// Strings in which no year can be found sort after all dates.
+func DateKey(s string) string {
+	s = strings.ToLower(s)
+
+	if m := isoDateRegex.FindStringSubmatch(s); m != nil {
+		y, _ := strconv.Atoi(m[1])
+		mo, _ := strconv.Atoi(m[2])
+		d, _ := strconv.Atoi(m[3])
+		return fmt.Sprintf("%04d%02d%02d", y, mo, d)
+	}
+
+	var (
+		y, mo, d int
+		found    bool
+	)
+	for _, w := range strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		if n, err := strconv.Atoi(strings.TrimPrefix(w, "c")); err == nil {
+			switch {
+			case len(w) >= 3 && !found:
+				y, found = n, true
+			case len(w) <= 2 && d == 0 && !found:
+				d = n
+			}
+			continue
+		}
+		if mo == 0 && len(w) >= 3 {
+			for i, name := range monthNames {
+				if strings.HasPrefix(name, w) {
+					mo = i + 1
+					break
+				}
+			}
+		}
+	}
+	if !found {
+		return "~"
+	}
+	return fmt.Sprintf("%04d%02d%02d", y, mo, d)
+}
+
+var (
+	isoDateRegex = regexp.MustCompile(`^\W*(?:c|ca\.?\s*|circa\s*)?(\d{4})(?:-(\d{1,2})(?:-(\d{1,2}))?)?\b`)
+	monthNames   = []string{"january", "february", "march", "april", "may", "june", "july", "august", "september", "october", "november", "december"}
+)
+
+// CallNumberKey converts a library call number to a sort key.
+// It handles Library of Congress ("QA76.73.G63 D66 2015")
+// and Dewey ("823.914 TOL") call numbers.
+//
+// The first number in a call number is compared as an integer (so QA9 precedes QA76),
+// as is any number following a letter and a period (as in "v.10").
+// The digits after a decimal point,
+// and those following a cutter letter ("G63"),
+// are compared as decimal fractions (so .G63 precedes .G7).
+func CallNumberKey(s string) string {
+	var (
+		parts        []string
+		sawNumber    bool
+		cutter       bool // the previous token was a cutter's letters, with nothing in between
+		prevDigitDot bool // the previous token was digits followed by a period and more digits
+	)
+
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		switch {
+		case r >= '0' && r <= '9':
+			digits := s[:runLen(s, isASCIIDigit)]
+			s = s[len(digits):]
+
+			if prevDigitDot || cutter {
+				parts = append(parts, digits)
+			} else {
+				parts = append(parts, encodeInt(digits))
+			}
+			sawNumber, cutter = true, false
+			prevDigitDot = len(s) > 1 && s[0] == '.' && isASCIIDigit(rune(s[1]))
+
+		case unicode.IsLetter(r):
+			letters := s[:runLen(s, unicode.IsLetter)]
+			s = s[len(letters):]
+
+			parts = append(parts, strings.ToLower(letters))
+
+			// Letters before the first number are the class letters.
+			// Letters after it begin a cutter.
+			cutter, prevDigitDot = sawNumber, false
+
+		default:
+			s = s[size:]
+			cutter = false
+			if r != '.' {
+				prevDigitDot = false
+			}
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// runLen returns the length in bytes of the prefix of s
+// whose runes all satisfy f.
+func runLen(s string, f func(rune) bool) int {
+	if i := strings.IndexFunc(s, func(r rune) bool { return !f(r) }); i >= 0 {
+		return i
+	}
+	return len(s)
+}
+
+func isASCIIDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// allDigits tells whether s is a nonempty string of ASCII digits.
+func allDigits(s string) bool {
+	return s != "" && runLen(s, isASCIIDigit) == len(s)
+}
+
+// encodeInt encodes a string of decimal digits
+// so that the lexical order of encodings
+// matches the numeric order of the numbers:
+// it is the number of significant digits, as two digits,
+// followed by the significant digits.
+func encodeInt(digits string) string {
+	digits = strings.TrimLeft(digits, "0")
+	return fmt.Sprintf("%02d%s", len(digits), digits)
+}
+
+// NumericKey converts a number to a sort key
+// that orders numbers by value.
+// Strings that do not parse as numbers (per [strconv.ParseFloat],
+// after removing commas)
+// sort after all numbers, in [Key] order.
+func NumericKey(s string) string {
+	f, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(s), ",", ""), 64)
+	if err != nil || math.IsNaN(f) {
+		return "~" + Key(s)
+	}
+
+	// Map the float's bits to an unsigned integer with the same ordering:
+	// flip all the bits of negative numbers, and just the sign bit of others.
+	bits := math.Float64bits(f)
+	if bits&(1<<63) != 0 {
+		bits = ^bits
+	} else {
+		bits |= 1 << 63
+	}
+	return fmt.Sprintf("%016x", bits)
+}
@@ -0,0 +1,146 @@
+package bib
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestPersonKey(t *testing.T) {
+	cases := []struct {
+		inp, want string
+	}{{
+		inp:  "Judd Apatow",
+		want: "apatow judd",
+	}, {
+		inp:  "Apatow, Judd",
+		want: "apatow judd",
+	}, {
+		inp:  "Martin Luther King Jr.",
+		want: "king martin luther jr",
+	}, {
+		inp:  "King, Martin Luther, Jr.",
+		want: "king martin luther jr",
+	}, {
+		inp:  "A. A. Milne",
+		want: "milne a a",
+	}, {
+		inp:  "Prince",
+		want: "prince",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := PersonKey(tc.inp)
+			if got != tc.want {
+				t.Errorf(`input "%s", got "%s", want "%s"`, tc.inp, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPersonDisplayForm(t *testing.T) {
+	cases := []struct {
+		inp, want string
+	}{{
+		inp:  "Judd Apatow",
+		want: "Apatow, Judd",
+	}, {
+		inp:  " Apatow, Judd ",
+		want: "Apatow, Judd",
+	}, {
+		inp:  "J. R. R. Tolkien",
+		want: "Tolkien, J. R. R.",
+	}, {
+		inp:  "Martin Luther King Jr.",
+		want: "King, Martin Luther, Jr.",
+	}, {
+		inp:  "Prince",
+		want: "Prince",
+	}, {
+		inp:  "",
+		want: "",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got := PersonDisplayForm(tc.inp); got != tc.want {
+				t.Errorf(`input "%s", got "%s", want "%s"`, tc.inp, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDateKey(t *testing.T) {
+	cases := []struct {
+		inp, want string
+	}{{
+		inp:  "2020-01-02",
+		want: "20200102",
+	}, {
+		inp:  "2020-1",
+		want: "20200100",
+	}, {
+		inp:  "1999-2001",
+		want: "19990000",
+	}, {
+		inp:  "c1999",
+		want: "19990000",
+	}, {
+		inp:  "[1999?]",
+		want: "19990000",
+	}, {
+		inp:  "ca. 1850",
+		want: "18500000",
+	}, {
+		inp:  "January 2, 2020",
+		want: "20200102",
+	}, {
+		inp:  "2 Sept. 1752",
+		want: "17520902",
+	}, {
+		inp:  "n.d.",
+		want: "~",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := DateKey(tc.inp)
+			if got != tc.want {
+				t.Errorf(`input "%s", got "%s", want "%s"`, tc.inp, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCallNumberKey(t *testing.T) {
+	// Each list is in the correct order.
+	cases := [][]string{
+		{"QA9 .B3", "QA76.73.G63 D66 2015", "QA76.73.G7 A1", "QA76.8 .A1", "QB1 .A1"},
+		{"823.9 ABC", "823.914 TOL", "823.92 ABC", "900 ABC"},
+		{"PS3545 .I345 v.2", "PS3545 .I345 v.10"},
+	}
+
+	for i, want := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := append([]string(nil), want...)
+			sort.Slice(got, func(i, j int) bool { return CallNumberKey(got[i]) < CallNumberKey(got[j]) })
+			for j := range got {
+				if got[j] != want[j] {
+					t.Errorf("got %v, want %v", got, want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestNumericKey(t *testing.T) {
+	want := []string{"-100", "-2.5", "0", "2", "10", "1,000", "abc"}
+	for i := 1; i < len(want); i++ {
+		a, b := NumericKey(want[i-1]), NumericKey(want[i])
+		if a >= b {
+			t.Errorf(`NumericKey("%s") = "%s", not less than NumericKey("%s") = "%s"`, want[i-1], a, want[i], b)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package bib
+
+import (
+	"net/url"
+	"strings"
+)
+
+// FilenameKey converts a file name or path to a bibliographic sort key.
+// It files on the base name,
+// without any extension,
+// and treats underscores as spaces.
+// Both slashes and backslashes are taken to be path separators,
+// and percent-escapes (as in file: URLs) are decoded.
+func FilenameKey(name string) string {
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	name = trimExt(name)
+	name = strings.ReplaceAll(name, "_", " ")
+	return Key(name)
+}
+
+// trimExt removes a file extension from name.
+// Only short alphanumeric suffixes count as extensions,
+// so that e.g. "Mr. Smith Goes to Washington" keeps its title intact.
+func trimExt(name string) string {
+	i := strings.LastIndexByte(name, '.')
+	if i <= 0 {
+		return name
+	}
+	ext := name[i+1:]
+	if len(ext) == 0 || len(ext) > 5 {
+		return name
+	}
+	for _, r := range ext {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return name
+		}
+	}
+	return name[:i]
+}
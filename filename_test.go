@@ -0,0 +1,36 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFilenameKey(t *testing.T) {
+	cases := []struct {
+		inp, want string
+	}{{
+		inp:  "The_Gumball_Rally.mkv",
+		want: "gumball rally",
+	}, {
+		inp:  "/music/42nd Street.flac",
+		want: "forty-second street",
+	}, {
+		inp:  `C:\Movies\The 40-Year-Old Virgin.avi`,
+		want: "forty year old virgin",
+	}, {
+		inp:  "file:///music/A%20Day%20in%20the%20Life.mp3",
+		want: "day in the life",
+	}, {
+		inp:  "Mr. Smith Goes to Washington",
+		want: "mr smith goes to washington",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := FilenameKey(tc.inp)
+			if got != tc.want {
+				t.Errorf(`input "%s", got "%s", want "%s"`, tc.inp, got, tc.want)
+			}
+		})
+	}
+}
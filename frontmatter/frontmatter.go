@@ -0,0 +1,369 @@
+// Package frontmatter sorts Markdown files bibliographically
+// by the title in their YAML or TOML front matter,
+// as used by static-site generators like Hugo and Jekyll,
+// and can write the computed sort keys back into the front matter
+// for the site's templates to sort by.
+//
+// Only as much of YAML and TOML is understood as is needed
+// to find a top-level title field and to set a top-level field.
+package frontmatter
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/bobg/bib"
+)
+
+// Page is a Markdown file with its title and sort key.
+type Page struct {
+	Path string
+
+	// Title is the title from the file's front matter,
+	// or "" if it has none.
+	Title string
+
+	// Key is the bibliographic sort key of the title,
+	// or of the file name if there is no title.
+	Key string
+}
+
+// Exts are the file-name extensions of the files that [ReadDir] reads.
+var Exts = map[string]bool{
+	".md":       true,
+	".markdown": true,
+}
+
+// ReadDir reads the Markdown files in the tree rooted at dir
+// and returns them in order of their keys.
+// Pages whose keys are equal are ordered by path.
+func ReadDir(dir string) ([]*Page, error) {
+	var pages []*Page
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !Exts[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		title, err := Title(content)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		pages = append(pages, &Page{Path: path, Title: title, Key: pageKey(path, title)})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pages, func(i, j int) bool {
+		if pages[i].Key != pages[j].Key {
+			return pages[i].Key < pages[j].Key
+		}
+		return pages[i].Path < pages[j].Path
+	})
+	return pages, nil
+}
+
+func pageKey(path, title string) string {
+	if title != "" {
+		return bib.Key(title)
+	}
+	return bib.FilenameKey(filepath.Base(path))
+}
+
+// WriteKey sets the named field in the page's front matter to its key
+// and rewrites the file.
+// See [SetField].
+func (p *Page) WriteKey(field string) error {
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(p.Path)
+	if err != nil {
+		return err
+	}
+	content, err = SetField(content, field, p.Key)
+	if err != nil {
+		return fmt.Errorf("%s: %w", p.Path, err)
+	}
+	return os.WriteFile(p.Path, content, info.Mode().Perm())
+}
+
+// frontMatter locates the front matter in content.
+// It returns the delimiter ("---" for YAML, "+++" for TOML),
+// the byte range of the lines between the delimiters,
+// and the line ending in use.
+// It returns an empty delimiter if there is no front matter.
+func frontMatter(content []byte) (delim string, start, end int, eol string) {
+	bom := 0
+	if bytes.HasPrefix(content, []byte("\ufeff")) {
+		bom = 3
+	}
+	for _, d := range []string{"---", "+++"} {
+		for _, e := range []string{"\n", "\r\n"} {
+			if !bytes.HasPrefix(content[bom:], []byte(d+e)) {
+				continue
+			}
+			start = bom + len(d) + len(e)
+			for i := start; i < len(content); {
+				next := len(content)
+				if j := bytes.IndexByte(content[i:], '\n'); j >= 0 {
+					next = i + j + 1
+				}
+				if strings.TrimRight(string(content[i:next]), "\r\n \t") == d {
+					return d, start, i, e
+				}
+				i = next
+			}
+			return "", 0, 0, ""
+		}
+	}
+	return "", 0, 0, ""
+}
+
+var (
+	yamlTitleRegex = regexp.MustCompile(`^title:[ \t]*(.*?)[ \t]*$`)
+	tomlTitleRegex = regexp.MustCompile(`^title[ \t]*=[ \t]*(.*?)[ \t]*$`)
+)
+
+// Title returns the top-level title field from the front matter of a Markdown document,
+// or "" if it has none.
+func Title(content []byte) (string, error) {
+	delim, start, end, _ := frontMatter(content)
+	if delim == "" {
+		return "", nil
+	}
+	lines := strings.Split(strings.ReplaceAll(string(content[start:end]), "\r\n", "\n"), "\n")
+
+	if delim == "+++" {
+		for _, line := range lines {
+			if strings.HasPrefix(strings.TrimSpace(line), "[") {
+				break // the start of a table; no more top-level fields
+			}
+			if m := tomlTitleRegex.FindStringSubmatch(line); m != nil {
+				return tomlString(m[1])
+			}
+		}
+		return "", nil
+	}
+
+	for i, line := range lines {
+		m := yamlTitleRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		v := m[1]
+		if v == "" || strings.HasPrefix(v, "|") || strings.HasPrefix(v, ">") {
+			// A block scalar or a plain scalar continued on indented lines.
+			var parts []string
+			if v != "" && v[0] != '|' && v[0] != '>' {
+				parts = append(parts, v)
+			}
+			for _, cont := range lines[i+1:] {
+				if cont == "" || (cont[0] != ' ' && cont[0] != '\t') {
+					break
+				}
+				parts = append(parts, strings.TrimSpace(cont))
+			}
+			return strings.Join(parts, " "), nil
+		}
+		return yamlString(v)
+	}
+	return "", nil
+}
+
+func yamlString(v string) (string, error) {
+	if v[0] == '"' || v[0] == '\'' {
+		return quoted(v, true)
+	}
+	if i := strings.Index(v, " #"); i >= 0 {
+		v = strings.TrimSpace(v[:i])
+	}
+	return v, nil
+}
+
+func tomlString(v string) (string, error) {
+	return quoted(v, false)
+}
+
+// quoted parses the quoted string at the start of v,
+// ignoring anything (such as a comment) after it.
+// Double-quoted strings have backslash escapes.
+// In single-quoted strings, backslashes are literal,
+// and if yaml is true a doubled quote stands for one quote.
+func quoted(v string, yaml bool) (string, error) {
+	switch {
+	case strings.HasPrefix(v, `"`):
+		for i := 1; i < len(v); i++ {
+			switch v[i] {
+			case '\\':
+				i++
+			case '"':
+				s, err := strconv.Unquote(v[:i+1])
+				if err != nil {
+					return "", fmt.Errorf("bad title %s: %w", v, err)
+				}
+				return s, nil
+			}
+		}
+
+	case strings.HasPrefix(v, "'"):
+		var buf strings.Builder
+		for i := 1; i < len(v); i++ {
+			if v[i] != '\'' {
+				buf.WriteByte(v[i])
+				continue
+			}
+			if yaml && i+1 < len(v) && v[i+1] == '\'' {
+				buf.WriteByte('\'')
+				i++
+				continue
+			}
+			return buf.String(), nil
+		}
+	}
+	return "", fmt.Errorf("bad title %s", v)
+}
+
+// SetField sets a top-level field in the front matter of a Markdown document to a string value,
+// replacing the field's existing line if there is one
+// (along with the indented lines that continue a YAML value,
+// as in a block scalar)
+// and otherwise adding a line at the end of the front matter.
+// The rest of the document is unchanged.
+// It is an error if the document has no front matter.
+func SetField(content []byte, field, value string) ([]byte, error) {
+	delim, start, end, eol := frontMatter(content)
+	if delim == "" {
+		return nil, fmt.Errorf("no front matter")
+	}
+
+	line := field + ": " + strconv.Quote(value)
+	fieldRegex := regexp.MustCompile(`^` + regexp.QuoteMeta(field) + `:`)
+	if delim == "+++" {
+		line = field + " = " + tomlQuote(value)
+		fieldRegex = regexp.MustCompile(`^` + regexp.QuoteMeta(field) + `[ \t]*=`)
+	}
+
+	var (
+		fm       = content[start:end]
+		buf      bytes.Buffer
+		replaced bool
+		inTable  bool
+		insertAt = len(fm) // where to add the line if there is no existing one
+	)
+	for i := 0; i < len(fm); {
+		next := len(fm)
+		if j := bytes.IndexByte(fm[i:], '\n'); j >= 0 {
+			next = i + j + 1
+		}
+		text := strings.TrimRight(string(fm[i:next]), "\r\n")
+		if delim == "+++" && strings.HasPrefix(strings.TrimSpace(text), "[") && !inTable {
+			// New top-level fields must precede the first table.
+			inTable, insertAt = true, i
+		}
+		if !replaced && !inTable && fieldRegex.MatchString(text) {
+			if delim == "---" {
+				next = yamlValueEnd(fm, next)
+			}
+			buf.Write(fm[:i])
+			buf.WriteString(line + eol)
+			buf.Write(fm[next:])
+			replaced = true
+		}
+		i = next
+	}
+
+	var out bytes.Buffer
+	out.Write(content[:start])
+	if replaced {
+		out.Write(buf.Bytes())
+	} else {
+		out.Write(fm[:insertAt])
+		if insertAt > 0 && fm[insertAt-1] != '\n' {
+			out.WriteString(eol)
+		}
+		out.WriteString(line + eol)
+		out.Write(fm[insertAt:])
+	}
+	out.Write(content[end:])
+	return out.Bytes(), nil
+}
+
+// yamlValueEnd returns the position in the YAML front matter fm
+// just past the lines that continue the value of a top-level field,
+// starting from the line at i after the field's own line.
+// Those are the lines that are indented,
+// and any blank lines among them.
+func yamlValueEnd(fm []byte, i int) int {
+	end := i
+	for i < len(fm) {
+		next := len(fm)
+		if j := bytes.IndexByte(fm[i:], '\n'); j >= 0 {
+			next = i + j + 1
+		}
+		text := strings.TrimRight(string(fm[i:next]), "\r\n")
+		switch {
+		case strings.TrimSpace(text) == "":
+			// A blank line belongs to the value only if an indented line follows it.
+		case text[0] == ' ' || text[0] == '\t':
+			end = next
+		default:
+			return end
+		}
+		i = next
+	}
+	return end
+}
+
+// tomlQuote returns s as a TOML basic string.
+// Unlike [strconv.Quote],
+// it never uses escapes that TOML lacks, like \x and \a.
+func tomlQuote(s string) string {
+	var buf strings.Builder
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\r':
+			buf.WriteString(`\r`)
+		default:
+			switch {
+			case unicode.IsPrint(r):
+				buf.WriteRune(r)
+			case r > 0xffff:
+				fmt.Fprintf(&buf, `\U%08X`, r)
+			default:
+				fmt.Fprintf(&buf, `\u%04X`, r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
@@ -0,0 +1,175 @@
+package frontmatter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTitle(t *testing.T) {
+	cases := []struct {
+		name, content, want string
+		value               string // "hobbit" if empty
+	}{{
+		name:    "yaml plain",
+		content: "---\nlayout: review\ntitle: The Hobbit # a classic\n---\nBody\n",
+		want:    "The Hobbit",
+	}, {
+		name:    "yaml double-quoted",
+		content: "---\ntitle: \"\\\"Heretics\\\" of Dune\"\n---\n",
+		want:    `"Heretics" of Dune`,
+	}, {
+		name:    "yaml single-quoted",
+		content: "---\ntitle: 'Alice''s Adventures' # comment\n---\n",
+		want:    "Alice's Adventures",
+	}, {
+		name:    "yaml folded",
+		content: "---\ntitle: >\n  A Very Long\n  Title\ndate: 2020-01-02\n---\n",
+		want:    "A Very Long Title",
+	}, {
+		name:    "yaml nested title ignored",
+		content: "---\nseries:\n  title: Middle-earth\n---\n",
+		want:    "",
+	}, {
+		name:    "yaml crlf",
+		content: "---\r\ntitle: Emma\r\n---\r\n",
+		want:    "Emma",
+	}, {
+		name:    "toml",
+		content: "+++\ntitle = \"42nd Street\" # musical\ndate = 2020-01-02\n+++\n",
+		want:    "42nd Street",
+	}, {
+		name:    "toml literal",
+		content: "+++\ntitle = 'C:\\Temp'\n+++\n",
+		want:    `C:\Temp`,
+	}, {
+		name:    "toml table title ignored",
+		content: "+++\n[params]\ntitle = \"Nope\"\n+++\n",
+		want:    "",
+	}, {
+		name:    "no front matter",
+		content: "# Just a heading\n",
+		want:    "",
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Title([]byte(tc.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSetField(t *testing.T) {
+	cases := []struct {
+		name, content, want string
+		value               string // "hobbit" if empty
+	}{{
+		name:    "yaml add",
+		content: "---\ntitle: The Hobbit\n---\nBody\n",
+		want:    "---\ntitle: The Hobbit\nsortkey: \"hobbit\"\n---\nBody\n",
+	}, {
+		name:    "yaml replace",
+		content: "---\nsortkey: old\ntitle: The Hobbit\n---\nBody\n",
+		want:    "---\nsortkey: \"hobbit\"\ntitle: The Hobbit\n---\nBody\n",
+	}, {
+		name:    "yaml crlf",
+		content: "---\r\ntitle: The Hobbit\r\n---\r\n",
+		want:    "---\r\ntitle: The Hobbit\r\nsortkey: \"hobbit\"\r\n---\r\n",
+	}, {
+		name:    "toml before table",
+		content: "+++\ntitle = \"The Hobbit\"\n[params]\nsortkey = \"nested\"\n+++\n",
+		want:    "+++\ntitle = \"The Hobbit\"\nsortkey = \"hobbit\"\n[params]\nsortkey = \"nested\"\n+++\n",
+	}, {
+		name:    "empty",
+		content: "---\n---\n",
+		want:    "---\nsortkey: \"hobbit\"\n---\n",
+	}, {
+		name:    "yaml replace block scalar",
+		content: "---\nsortkey: |\n  old\n\n  key\ntitle: The Hobbit\n---\n",
+		want:    "---\nsortkey: \"hobbit\"\ntitle: The Hobbit\n---\n",
+	}, {
+		name:    "yaml replace folded last",
+		content: "---\ntitle: The Hobbit\nsortkey: >-\n  old key\n\n---\n",
+		want:    "---\ntitle: The Hobbit\nsortkey: \"hobbit\"\n\n---\n",
+	}, {
+		name:    "toml escapes",
+		content: "+++\ntitle = \"x\"\n+++\n",
+		value:   "a\x01b\a\v\"\\\u00e9\u2028",
+		want:    "+++\ntitle = \"x\"\nsortkey = \"a\\u0001b\\u0007\\u000B\\\"\\\\\u00e9\\u2028\"\n+++\n",
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			value := tc.value
+			if value == "" {
+				value = "hobbit"
+			}
+			got, err := SetField([]byte(tc.content), "sortkey", value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tc.want {
+				t.Errorf("got:\n%q\nwant:\n%q", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := SetField([]byte("no front matter"), "sortkey", "x"); err == nil {
+		t.Error("got no error for a document without front matter")
+	}
+}
+
+func TestReadDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"hobbit.md":         "---\ntitle: The Hobbit\n---\n",
+		"sub/dune.markdown": "+++\ntitle = \"Dune\"\n+++\n",
+		"an-apple-a-day.md": "No front matter.\n",
+		"notes.txt":         "---\ntitle: Ignored\n---\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pages, err := ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, p := range pages {
+		rel, _ := filepath.Rel(dir, p.Path)
+		got = append(got, rel+"="+p.Key)
+	}
+	want := []string{"an-apple-a-day.md=apple a day", filepath.Join("sub", "dune.markdown") + "=dune", "hobbit.md=hobbit"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+
+	if err := pages[2].WriteKey("sortkey"); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(pages[2].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "---\ntitle: The Hobbit\nsortkey: \"hobbit\"\n---\n"; string(content) != want {
+		t.Errorf("got %q, want %q", content, want)
+	}
+}
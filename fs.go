@@ -0,0 +1,36 @@
+package bib
+
+import (
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/bobg/go-generics/v4/slices"
+)
+
+// SortDirEntries sorts directory entries bibliographically by name,
+// using [FilenameKey] for files.
+// Directory names are keyed the same way,
+// except that nothing is removed as a file extension.
+func SortDirEntries(entries []fs.DirEntry) {
+	keys := slices.Map(entries, dirEntryKey)
+	slices.KeyedSort(entries, sort.StringSlice(keys))
+}
+
+func dirEntryKey(e fs.DirEntry) string {
+	name := e.Name()
+	if e.IsDir() {
+		return Key(strings.ReplaceAll(name, "_", " "))
+	}
+	return FilenameKey(name)
+}
+
+// ReadDirSorted is like [fs.ReadDir]
+// but returns the entries in bibliographic order
+// (see [SortDirEntries])
+// rather than sorted by file name.
+func ReadDirSorted(fsys fs.FS, name string) ([]fs.DirEntry, error) {
+	entries, err := fs.ReadDir(fsys, name)
+	SortDirEntries(entries)
+	return entries, err
+}
@@ -0,0 +1,41 @@
+package bib
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestReadDirSorted(t *testing.T) {
+	fsys := fstest.MapFS{
+		"music/The_Zoo.mp3":           {},
+		"music/42nd Street.flac":      {},
+		"music/An Apple a Day.ogg":    {},
+		"music/Mr.Bean/track.mp3":     {},
+		"music/The 40-Year-Old.m4a":   {},
+		"music/_/placeholder.txt":     {},
+		"music/Nine Inch Nails/x.mp3": {},
+	}
+
+	entries, err := ReadDirSorted(fsys, "music")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name())
+	}
+	want := []string{
+		"_",
+		"An Apple a Day.ogg",
+		"The 40-Year-Old.m4a",
+		"42nd Street.flac",
+		"Mr.Bean",
+		"Nine Inch Nails",
+		"The_Zoo.mp3",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+package bib
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// graphemeLen returns the length in bytes of the grapheme cluster
+// (the user-perceived character) at the start of s.
+//
+// It follows the extended grapheme cluster rules of Unicode Standard Annex #29
+// as far as they matter for keys:
+// combining and spacing marks stay with their base,
+// regional indicators pair up into flags,
+// and emoji joined by zero-width joiners stay together with their modifiers.
+// Other rules (for Hangul jamo sequences and prepended marks, for instance)
+// are not needed, since they never join characters that normalization treats differently.
+func graphemeLen(s string) int {
+	r, n := utf8.DecodeRuneInString(s)
+	if r == '\r' && len(s) > n && s[n] == '\n' {
+		return n + 1
+	}
+	if unicode.IsControl(r) {
+		return n
+	}
+
+	if isRegionalIndicator(r) {
+		if r2, n2 := utf8.DecodeRuneInString(s[n:]); isRegionalIndicator(r2) {
+			n += n2
+		}
+	}
+
+	var (
+		pict    = isPictographic(r)
+		joining bool // the previous rune was a zero-width joiner following a pictograph
+	)
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		switch {
+		case r == zwj:
+			joining = pict
+		case isGraphemeExtend(r):
+			joining = false
+		case joining && isPictographic(r):
+			joining = false
+		default:
+			return n
+		}
+		n += size
+	}
+	return n
+}
+
+const zwj = '\u200d' // zero-width joiner
+
+func isGraphemeExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r == '\u200c' || // zero-width non-joiner
+		(r >= 0x1f3fb && r <= 0x1f3ff) || // emoji skin-tone modifiers
+		(r >= 0xe0020 && r <= 0xe007f) // tags, as in subdivision flags
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// isPictographic approximates the Extended_Pictographic property:
+// the characters that can appear in emoji sequences.
+func isPictographic(r rune) bool {
+	switch {
+	case r == 0xa9, r == 0xae, r == 0x203c, r == 0x2049, r == 0x2122, r == 0x2139,
+		r >= 0x2194 && r <= 0x21aa,
+		r >= 0x231a && r <= 0x23ff,
+		r >= 0x25aa && r <= 0x25fe,
+		r >= 0x2600 && r <= 0x27bf,
+		r >= 0x2934 && r <= 0x2935,
+		r >= 0x2b05 && r <= 0x2b55,
+		r == 0x3030, r == 0x303d, r == 0x3297, r == 0x3299,
+		r >= 0x1f000 && r <= 0x1faff:
+		return true
+	}
+	return false
+}
+
+// romanNumeralForms are the letters of the Roman numerals
+// from U+2160 (Ⅰ) through U+216F (Ⅿ),
+// and of their lowercase forms from U+2170 (ⅰ) through U+217F (ⅿ).
+var romanNumeralForms = [16]string{
+	"i", "ii", "iii", "iv", "v", "vi", "vii", "viii", "ix", "x", "xi", "xii", "l", "c", "d", "m",
+}
+
+// normalizeGraphemes keeps the grapheme clusters of s
+// that are letters or digits,
+// together with their combining marks,
+// turns dashes into spaces,
+// and drops everything else,
+// so that a multi-character symbol like a flag or an emoji sequence
+// is dropped as a whole.
+// Variation selectors and enclosing marks are dropped from kept clusters,
+// so the keycap "1️⃣" becomes "1."
+// Roman numeral characters become the letters they stand for,
+// so "Ⅷ" becomes "viii."
+func normalizeGraphemes(s string) string {
+	var buf strings.Builder
+	for len(s) > 0 {
+		n := graphemeLen(s)
+		cluster := s[:n]
+		s = s[n:]
+
+		base, size := utf8.DecodeRuneInString(cluster)
+		switch {
+		case unicode.IsSpace(base), unicode.In(base, unicode.Pd):
+			buf.WriteByte(' ')
+		case base >= 0x2160 && base <= 0x217f:
+			buf.WriteString(romanNumeralForms[(base-0x2160)%16])
+		case unicode.IsLetter(base), unicode.IsNumber(base):
+			buf.WriteRune(base)
+			for _, r := range cluster[size:] {
+				if unicode.In(r, unicode.Mn, unicode.Mc) && !unicode.Is(unicode.Variation_Selector, r) {
+					buf.WriteRune(r)
+				}
+			}
+		}
+	}
+	return buf.String()
+}
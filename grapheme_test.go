@@ -0,0 +1,90 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGraphemeLen(t *testing.T) {
+	cases := []struct {
+		inp  string
+		want int
+	}{
+		{"abc", 1},
+		{"éx", 3},
+		{"\r\nx", 2},
+		{"\U0001F1FA\U0001F1F8\U0001F1EC", 8}, // US flag, then a lone regional indicator
+		{"\U0001F468\u200d\U0001F469\u200d\U0001F467x", 18},        // family
+		{"\U0001F44D\U0001F3FDx", 8},                               // thumbs up, medium skin tone
+		{"1\ufe0f\u20e3x", 7},                                      // keycap
+		{"เรียน", 3},                                               // Thai: เ is a cluster of its own
+		{"รี", 6},                                                  // Thai: ร with its vowel mark
+		{"\U0001F3F4\U000E0067\U000E0062\U000E007F\U0001F600", 16}, // a subdivision flag
+	}
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got := graphemeLen(tc.inp); got != tc.want {
+				t.Errorf("%q: got %d, want %d", tc.inp, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGraphemeKeys(t *testing.T) {
+	cases := []struct {
+		inp  string
+		want string
+	}{{
+		inp:  "Nai\u0308ve Art", // with a combining diaeresis
+		want: "nai\u0308ve art",
+	}, {
+		inp:  "\U0001F1FA\U0001F1F8 Flags of the World",
+		want: "flags of the world",
+	}, {
+		inp:  "The \U0001F468\u200d\U0001F469\u200d\U0001F467 Family",
+		want: "family",
+	}, {
+		inp:  "1\ufe0f\u20e3 Step",
+		want: "one step",
+	}, {
+		inp:  "เรียน",
+		want: "เรียน",
+	}, {
+		inp:  "Hello—World",
+		want: "hello world",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got := Key(tc.inp); got != tc.want {
+				t.Errorf("input %q, got %q, want %q", tc.inp, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRomanNumeralForms(t *testing.T) {
+	cases := []struct {
+		inp, want string
+	}{{
+		inp:  "Henry Ⅷ",
+		want: "Henry VIII",
+	}, {
+		inp:  "Rocky Ⅳ",
+		want: "Rocky IV",
+	}, {
+		inp:  "Chapter ⅻ",
+		want: "Chapter XII",
+	}, {
+		inp:  "Ⅿ",
+		want: "M",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got, want := Key(tc.inp), Key(tc.want); got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
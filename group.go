@@ -0,0 +1,62 @@
+package bib
+
+import (
+	"sort"
+)
+
+// Group is one group of items produced by [GroupSort].
+type Group[T any] struct {
+	// Name is the grouping value of the group's first item,
+	// suitable as a heading.
+	Name string
+
+	// Items are the group's items in sorted order.
+	Items []T
+}
+
+// GroupSort groups items by one field and sorts them by another,
+// as on a "browse by author" page.
+// The group and item functions return the values of those fields
+// (e.g. an author's name and a title),
+// which are compared by their [Key].
+// Since Key does not invert names,
+// a group function returning personal names should give them inverted
+// ("King, Stephen").
+//
+// The groups are in order of their keys,
+// with items whose grouping values have the same key in the same group
+// (so "King, Stephen" and "KING, STEPHEN." are one group),
+// and the items in each group are in order of their item keys.
+// Items whose keys are equal keep their original relative order.
+// Values with nothing to file on, such as empty strings,
+// sort before all others
+// (or after; see [WithPlacement]).
+func GroupSort[T any](items []T, group, item func(T) string) []Group[T] {
+	type keyed struct {
+		groupKey, itemKey string
+		val               T
+	}
+	var (
+		k   = Default()
+		all = make([]keyed, 0, len(items))
+	)
+	for _, it := range items {
+		all = append(all, keyed{groupKey: k.Key(group(it)), itemKey: k.Key(item(it)), val: it})
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].groupKey != all[j].groupKey {
+			return all[i].groupKey < all[j].groupKey
+		}
+		return all[i].itemKey < all[j].itemKey
+	})
+
+	var result []Group[T]
+	for i, e := range all {
+		if i == 0 || e.groupKey != all[i-1].groupKey {
+			result = append(result, Group[T]{Name: group(e.val)})
+		}
+		g := &result[len(result)-1]
+		g.Items = append(g.Items, e.val)
+	}
+	return result
+}
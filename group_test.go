@@ -0,0 +1,37 @@
+package bib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroupSort(t *testing.T) {
+	type book struct {
+		author, title string
+	}
+	books := []book{
+		{"King, Stephen", "The Stand"},
+		{"Austen, Jane", "Persuasion"},
+		{"KING, STEPHEN.", "Carrie"},
+		{"", "Beowulf"},
+		{"Austen, Jane", "Emma"},
+		{"King, Stephen", "It"},
+		{"Austen, Jane", "Emma"},
+	}
+
+	got := GroupSort(books, func(b book) string { return b.author }, func(b book) string { return b.title })
+
+	want := []Group[book]{{
+		Name:  "",
+		Items: []book{{"", "Beowulf"}},
+	}, {
+		Name:  "Austen, Jane",
+		Items: []book{{"Austen, Jane", "Emma"}, {"Austen, Jane", "Emma"}, {"Austen, Jane", "Persuasion"}},
+	}, {
+		Name:  "KING, STEPHEN.",
+		Items: []book{{"KING, STEPHEN.", "Carrie"}, {"King, Stephen", "It"}, {"King, Stephen", "The Stand"}},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
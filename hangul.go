@@ -0,0 +1,137 @@
+package bib
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// HangulPolicy says how a [Keyer] treats Korean text.
+type HangulPolicy int
+
+const (
+	// HangulAsWritten keeps Hangul as written.
+	// Keys in Hangul sort after those in Latin script,
+	// in the order of the Korean alphabet.
+	// This is the default.
+	HangulAsWritten HangulPolicy = iota
+
+	// HangulBlock keeps Hangul as written,
+	// but prefixes keys beginning with Hangul with "~,"
+	// so that Korean titles file together after those in Latin script
+	// and before those in most other scripts.
+	HangulBlock
+
+	// HangulRomanized converts Hangul to the Revised Romanization of Korean,
+	// so that Korean titles interfile with romanized ones:
+	// "서울" files as "seoul" and "한국어" as "hangugeo."
+	// Syllables are romanized one at a time,
+	// except that a final consonant followed by a silent initial carries over ("gugeo")
+	// and ㄹ assimilates a neighboring ㄴ or ㄹ ("silla").
+	// Other sound changes across syllable boundaries are not applied.
+	HangulRomanized
+)
+
+// WithHangul sets the policy for Korean text.
+// The default is [HangulAsWritten].
+func WithHangul(p HangulPolicy) Option {
+	return func(c *config) {
+		c.hangul = p
+	}
+}
+
+const (
+	hangulBase       = 0xAC00
+	hangulLast       = 0xD7A3
+	hangulVowelCount = 21
+	hangulFinalCount = 28
+)
+
+func isHangulSyllable(r rune) bool {
+	return r >= hangulBase && r <= hangulLast
+}
+
+var (
+	hangulInitials = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+	hangulVowels   = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+
+	// hangulFinals are the final consonants as pronounced at the end of a syllable.
+	hangulFinals = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+
+	// hangulLinked are the final consonants as pronounced before a silent initial,
+	// which they become the initial of.
+	// A double final keeps its first consonant and carries the second.
+	hangulLinked = []string{"", "g", "kk", "ks", "n", "nj", "nh", "d", "r", "lg", "lm", "lb", "ls", "lt", "lp", "lh", "m", "b", "bs", "s", "ss", "ng", "j", "ch", "k", "t", "p", "h"}
+)
+
+const (
+	hangulSilentInitial = 11 // ㅇ
+	hangulRieulInitial  = 5  // ㄹ
+	hangulNieunInitial  = 2  // ㄴ
+	hangulRieulFinal    = 8  // ㄹ
+	hangulNieunFinal    = 4  // ㄴ
+	hangulIeungFinal    = 21 // ㅇ, which is never carried over
+)
+
+// romanizeHangul converts the Hangul syllables in a word
+// to the Revised Romanization,
+// leaving other characters alone.
+func romanizeHangul(word string) string {
+	if strings.IndexFunc(word, isHangulSyllable) < 0 {
+		return word
+	}
+
+	var (
+		buf   strings.Builder
+		final = -1 // the pending final consonant of the previous syllable, if any
+	)
+	flush := func() {
+		if final > 0 {
+			buf.WriteString(hangulFinals[final])
+		}
+		final = -1
+	}
+	for _, r := range word {
+		if !isHangulSyllable(r) {
+			flush()
+			buf.WriteRune(r)
+			continue
+		}
+
+		var (
+			idx = int(r - hangulBase)
+			ini = idx / (hangulVowelCount * hangulFinalCount)
+			vow = idx / hangulFinalCount % hangulVowelCount
+			fin = idx % hangulFinalCount
+		)
+
+		initial := hangulInitials[ini]
+		switch {
+		case final <= 0:
+		case ini == hangulSilentInitial && final != hangulIeungFinal:
+			initial = hangulLinked[final]
+			final = -1
+		case (final == hangulRieulFinal || final == hangulNieunFinal) && ini == hangulRieulInitial,
+			final == hangulRieulFinal && ini == hangulNieunInitial:
+			// 신라 → silla, 설날 → seollal
+			buf.WriteString("l")
+			initial, final = "l", -1
+		}
+		flush()
+
+		buf.WriteString(initial)
+		buf.WriteString(hangulVowels[vow])
+		final = fin
+	}
+	flush()
+	return buf.String()
+}
+
+// applyHangul applies the Hangul policy to a finished key.
+func (k *Keyer) applyHangul(key string) string {
+	if k.cfg.hangul == HangulBlock {
+		if r, _ := utf8.DecodeRuneInString(key); isHangulSyllable(r) {
+			return "~" + key
+		}
+	}
+	return key
+}
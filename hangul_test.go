@@ -0,0 +1,63 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHangul(t *testing.T) {
+	cases := []struct {
+		inp    string
+		policy HangulPolicy
+		want   string
+	}{{
+		inp:    "서울",
+		policy: HangulAsWritten,
+		want:   "서울",
+	}, {
+		inp:    "서울",
+		policy: HangulBlock,
+		want:   "~서울",
+	}, {
+		inp:    "The Seoul Guide",
+		policy: HangulBlock,
+		want:   "seoul guide",
+	}, {
+		inp:    "서울",
+		policy: HangulRomanized,
+		want:   "seoul",
+	}, {
+		inp:    "한국어 사전",
+		policy: HangulRomanized,
+		want:   "hangugeo sajeon",
+	}, {
+		inp:    "대한민국",
+		policy: HangulRomanized,
+		want:   "daehanminguk",
+	}, {
+		inp:    "신라",
+		policy: HangulRomanized,
+		want:   "silla",
+	}, {
+		inp:    "부산행",
+		policy: HangulRomanized,
+		want:   "busanhaeng",
+	}, {
+		inp:    "김치",
+		policy: HangulRomanized,
+		want:   "gimchi",
+	}, {
+		inp:    "K-pop 아이돌",
+		policy: HangulRomanized,
+		want:   "k pop aidol",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(WithHangul(tc.policy)).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`input "%s", policy %d, got "%s", want "%s"`, tc.inp, tc.policy, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package bib
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"hash/fnv"
+	"strings"
+)
+
+// HashKey returns a 64-bit hash of the bibliographic sort key of s.
+// Strings with the same key have the same hash,
+// so it is suitable for bucketing or sharding by sort identity.
+// Hashes are not ordered the way keys are.
+//
+// The hash function is 64-bit FNV-1a,
+// so hashes are stable across processes and platforms.
+// They change only when the key for s changes.
+func HashKey(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(Key(s)))
+	return h.Sum64()
+}
+
+// HashKeyBase32 returns [HashKey] of s
+// as a fixed-length, 13-character string
+// using the lowercase "extended hex" base32 alphabet.
+// Lexical order of these strings matches the numeric order of the hashes.
+func HashKeyBase32(s string) string {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], HashKey(s))
+	return strings.ToLower(base32Encoding.EncodeToString(buf[:]))
+}
+
+var base32Encoding = base32.HexEncoding.WithPadding(base32.NoPadding)
@@ -0,0 +1,59 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHashKey(t *testing.T) {
+	a, b := HashKey("The Gumball Rally"), HashKey("gumball rally!")
+	if a != b {
+		t.Errorf("strings with equal keys have different hashes %x and %x", a, b)
+	}
+	if c := HashKey("Gumball Rallies"); c == a {
+		t.Errorf("strings with different keys have the same hash %x", a)
+	}
+
+	s := HashKeyBase32("The Gumball Rally")
+	if len(s) != 13 {
+		t.Errorf(`got "%s", want 13 characters`, s)
+	}
+	if s2 := HashKeyBase32("gumball rally!"); s2 != s {
+		t.Errorf(`got "%s" and "%s" for strings with equal keys`, s, s2)
+	}
+}
+
+// TestHashKeyStable checks that hashes do not change,
+// since callers may store them or compare them across processes.
+// They may change only when the keys of these inputs do
+// (which requires a new keyVersion).
+func TestHashKeyStable(t *testing.T) {
+	cases := []struct {
+		inp    string
+		hash   uint64
+		base32 string
+	}{{
+		inp:    "",
+		hash:   0xcbf29ce484222325,
+		base32: "pfp9pp4448hia",
+	}, {
+		inp:    "The Gumball Rally",
+		hash:   0x1c330e357932ff1d,
+		base32: "3gpgsdbp6bvhq",
+	}, {
+		inp:    "Pride & Prejudice",
+		hash:   0xa70326be4738c707,
+		base32: "ks1idfi7733ge",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got := HashKey(tc.inp); got != tc.hash {
+				t.Errorf("got %#x, want %#x", got, tc.hash)
+			}
+			if got := HashKeyBase32(tc.inp); got != tc.base32 {
+				t.Errorf(`got "%s", want "%s"`, got, tc.base32)
+			}
+		})
+	}
+}
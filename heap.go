@@ -0,0 +1,93 @@
+package bib
+
+import (
+	"container/heap"
+)
+
+// Heap is a priority queue of strings
+// that pops them in bibliographic order,
+// as for a pipeline that emits titles in sorted order as they arrive.
+// Of strings whose keys are equal,
+// the one pushed first is popped first
+// (unless the Keyer has a tie-breaking function; see [WithTieBreak]).
+//
+// The zero Heap is empty and ready to use,
+// with the default [Keyer] (see [SetDefault]).
+// A Heap is not safe for concurrent use.
+type Heap struct {
+	h stringHeap
+}
+
+// NewHeap produces a [Heap] containing strs.
+// It uses the default [Keyer] (see [SetDefault]).
+func NewHeap(strs ...string) *Heap {
+	return Default().NewHeap(strs...)
+}
+
+// NewHeap produces a [Heap] containing strs
+// and ordered by k.
+func (k *Keyer) NewHeap(strs ...string) *Heap {
+	h := &Heap{h: stringHeap{k: k}}
+	for _, s := range strs {
+		h.h.entries = append(h.h.entries, h.h.keyed(s))
+	}
+	heap.Init(&h.h)
+	return h
+}
+
+// Len tells the number of strings in h.
+func (h *Heap) Len() int {
+	return len(h.h.entries)
+}
+
+// Push adds s to h.
+func (h *Heap) Push(s string) {
+	if h.h.k == nil {
+		h.h.k = Default()
+	}
+	heap.Push(&h.h, h.h.keyed(s))
+}
+
+// Pop removes and returns the bibliographically first string in h.
+// It panics if h is empty.
+func (h *Heap) Pop() string {
+	return heap.Pop(&h.h).(indexedKeyed).Orig
+}
+
+// Peek returns the bibliographically first string in h
+// without removing it.
+// It panics if h is empty.
+func (h *Heap) Peek() string {
+	return h.h.entries[0].Orig
+}
+
+// stringHeap implements [heap.Interface].
+type stringHeap struct {
+	k       *Keyer
+	entries []indexedKeyed
+	seq     int // the number of strings ever added, for ordering equal keys
+}
+
+func (h *stringHeap) keyed(s string) indexedKeyed {
+	h.seq++
+	return indexedKeyed{KeyedString: KeyedString{Orig: s, Key: h.k.Key(s)}, index: h.seq}
+}
+
+func (h stringHeap) Len() int { return len(h.entries) }
+
+func (h stringHeap) Less(i, j int) bool {
+	if c := h.k.compareKeyed(h.entries[i].KeyedString, h.entries[j].KeyedString); c != 0 {
+		return c < 0
+	}
+	return h.entries[i].index < h.entries[j].index
+}
+
+func (h stringHeap) Swap(i, j int) { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+
+func (h *stringHeap) Push(x any) { h.entries = append(h.entries, x.(indexedKeyed)) }
+
+func (h *stringHeap) Pop() any {
+	e := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return e
+}
@@ -0,0 +1,44 @@
+package bib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHeap(t *testing.T) {
+	h := NewHeap("Zulu", "The Heat", "Mike")
+	h.Push("Alpha")
+	h.Push("Heat")
+
+	var got []string
+	if p := h.Peek(); p != "Alpha" {
+		t.Errorf("peeked %q, want Alpha", p)
+	}
+	got = append(got, h.Pop(), h.Pop())
+
+	h.Push("Echo")
+	h.Push("A Heat")
+	for h.Len() > 0 {
+		got = append(got, h.Pop())
+	}
+
+	want := []string{"Alpha", "The Heat", "Echo", "Heat", "A Heat", "Mike", "Zulu"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestZeroHeap(t *testing.T) {
+	var h Heap
+	for _, s := range []string{"Mike", "An Echo", "Bravo"} {
+		h.Push(s)
+	}
+	var got []string
+	for h.Len() > 0 {
+		got = append(got, h.Pop())
+	}
+	want := []string{"Bravo", "An Echo", "Mike"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,273 @@
+// Package index builds back-of-book indexes.
+//
+// Entries are added to a [Builder] one heading/locator pair at a time,
+// along with any "see" and "see also" cross-references.
+// The Builder merges the locators of identical headings,
+// files headings and subheadings using bibliographic sort keys,
+// and produces a structured index that can be rendered with [Write].
+package index
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/bobg/bib"
+)
+
+// Builder accumulates index entries.
+// The zero value is ready to use.
+type Builder struct {
+	// Keyer files headings and subheadings.
+	// If this is nil, [bib.Default] is used.
+	Keyer *bib.Keyer
+
+	// SubKeyer, if not nil, files subheadings in place of Keyer.
+	// For an index in the style of the Chicago Manual of Style,
+	// use the "cmos" and "cmos-subentry" presets (see [bib.PresetKeyer])
+	// for Keyer and SubKeyer.
+	SubKeyer *bib.Keyer
+
+	headings map[string]*Heading
+}
+
+// Heading is a main heading or subheading of an index.
+type Heading struct {
+	Text string
+
+	// Locators are the places the heading is indexed,
+	// typically page numbers or page ranges,
+	// without duplicates and in page order.
+	Locators []string
+
+	// See and SeeAlso are the headings that this one refers to,
+	// in filing order.
+	See, SeeAlso []string
+
+	// Subheadings are in filing order.
+	// Subheadings have no subheadings of their own.
+	Subheadings []*Heading
+
+	subheadings map[string]*Heading
+}
+
+// Add adds a locator (such as a page number or a range like "45–47")
+// for a heading and optional subheading.
+// Headings are identified by their text,
+// ignoring leading and trailing whitespace.
+// An empty locator adds the heading without a locator,
+// e.g. for a heading that has only subheadings.
+func (b *Builder) Add(heading, subheading, locator string) {
+	h := b.heading(heading)
+	if h == nil {
+		return
+	}
+	if subheading = strings.TrimSpace(subheading); subheading != "" {
+		if h.subheadings == nil {
+			h.subheadings = make(map[string]*Heading)
+		}
+		sub, ok := h.subheadings[subheading]
+		if !ok {
+			sub = &Heading{Text: subheading}
+			h.subheadings[subheading] = sub
+		}
+		h = sub
+	}
+	if locator = strings.TrimSpace(locator); locator != "" {
+		h.Locators = append(h.Locators, locator)
+	}
+}
+
+// See adds a cross-reference from heading to target,
+// for a heading that is not itself indexed
+// ("Pomme. See Apple").
+func (b *Builder) See(heading, target string) {
+	if h := b.heading(heading); h != nil {
+		if target = strings.TrimSpace(target); target != "" {
+			h.See = append(h.See, target)
+		}
+	}
+}
+
+// SeeAlso adds a cross-reference from heading to a related target
+// ("Fruit. See also Apple").
+func (b *Builder) SeeAlso(heading, target string) {
+	if h := b.heading(heading); h != nil {
+		if target = strings.TrimSpace(target); target != "" {
+			h.SeeAlso = append(h.SeeAlso, target)
+		}
+	}
+}
+
+func (b *Builder) heading(text string) *Heading {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	if b.headings == nil {
+		b.headings = make(map[string]*Heading)
+	}
+	h, ok := b.headings[text]
+	if !ok {
+		h = &Heading{Text: text}
+		b.headings[text] = h
+	}
+	return h
+}
+
+// Build returns the index's main headings in filing order,
+// with their locators and cross-references merged and sorted.
+// Headings with the same sort key are ordered by their text.
+func (b *Builder) Build() []*Heading {
+	k := b.Keyer
+	if k == nil {
+		k = bib.Default()
+	}
+	subk := b.SubKeyer
+	if subk == nil {
+		subk = k
+	}
+
+	result := make([]*Heading, 0, len(b.headings))
+	for _, h := range b.headings {
+		result = append(result, h)
+		h.Subheadings = h.Subheadings[:0]
+		for _, sub := range h.subheadings {
+			h.Subheadings = append(h.Subheadings, sub)
+			sub.Locators = sortLocators(sub.Locators)
+		}
+		sortHeadings(subk, h.Subheadings)
+		h.Locators = sortLocators(h.Locators)
+		h.See = sortTexts(k, h.See)
+		h.SeeAlso = sortTexts(k, h.SeeAlso)
+	}
+	sortHeadings(k, result)
+	return result
+}
+
+func sortHeadings(k *bib.Keyer, hs []*Heading) {
+	keys := make(map[*Heading]string, len(hs))
+	for _, h := range hs {
+		keys[h] = k.Key(h.Text)
+	}
+	sort.Slice(hs, func(i, j int) bool {
+		if keys[hs[i]] != keys[hs[j]] {
+			return keys[hs[i]] < keys[hs[j]]
+		}
+		return hs[i].Text < hs[j].Text
+	})
+}
+
+// sortTexts sorts and removes duplicates from a list of cross-reference targets.
+func sortTexts(k *bib.Keyer, texts []string) []string {
+	sort.Slice(texts, func(i, j int) bool {
+		ki, kj := k.Key(texts[i]), k.Key(texts[j])
+		if ki != kj {
+			return ki < kj
+		}
+		return texts[i] < texts[j]
+	})
+	return dedupe(texts)
+}
+
+// sortLocators sorts and removes duplicates from a list of locators.
+// Roman-numeral page numbers (front matter) come first,
+// then arabic ones in numeric order,
+// then anything else.
+func sortLocators(locs []string) []string {
+	sort.SliceStable(locs, func(i, j int) bool {
+		return locatorKey(locs[i]) < locatorKey(locs[j])
+	})
+	return dedupe(locs)
+}
+
+func locatorKey(loc string) string {
+	first := strings.IndexFunc(loc, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	if first < 0 {
+		first = len(loc)
+	}
+	if n, ok := roman(loc[:first]); ok {
+		return fmt.Sprintf("0%020d%s", n, loc[first:])
+	}
+	digits := strings.IndexFunc(loc, func(r rune) bool { return r < '0' || r > '9' })
+	if digits < 0 {
+		digits = len(loc)
+	}
+	if digits > 0 {
+		n, err := strconv.ParseUint(loc[:digits], 10, 64)
+		if err == nil {
+			return fmt.Sprintf("1%020d%s", n, loc[digits:])
+		}
+	}
+	return "2" + loc
+}
+
+// roman parses a lowercase roman numeral.
+func roman(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+	values := map[byte]int{'i': 1, 'v': 5, 'x': 10, 'l': 50, 'c': 100, 'd': 500, 'm': 1000}
+	n := 0
+	for i := 0; i < len(s); i++ {
+		v, ok := values[s[i]]
+		if !ok {
+			return 0, false
+		}
+		if i+1 < len(s) && values[s[i+1]] > v {
+			n -= v
+		} else {
+			n += v
+		}
+	}
+	return n, true
+}
+
+func dedupe(strs []string) []string {
+	var result []string
+	for i, s := range strs {
+		if i == 0 || s != strs[i-1] {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// Write renders an index as text in the style of the Chicago Manual of Style,
+// one heading per line, with subheadings indented:
+//
+//	Apple, 3, 12–14. See also Fruit
+//	    varieties of, 5
+//	Pomme. See Apple
+func Write(w io.Writer, headings []*Heading) error {
+	bw := bufio.NewWriter(w)
+	for _, h := range headings {
+		writeHeading(bw, "", h)
+		for _, sub := range h.Subheadings {
+			writeHeading(bw, "    ", sub)
+		}
+	}
+	return bw.Flush()
+}
+
+func writeHeading(w *bufio.Writer, indent string, h *Heading) {
+	w.WriteString(indent)
+	w.WriteString(h.Text)
+	for _, loc := range h.Locators {
+		w.WriteString(", ")
+		w.WriteString(loc)
+	}
+	if len(h.See) > 0 {
+		w.WriteString(". See ")
+		w.WriteString(strings.Join(h.See, "; "))
+	}
+	if len(h.SeeAlso) > 0 {
+		w.WriteString(". See also ")
+		w.WriteString(strings.Join(h.SeeAlso, "; "))
+	}
+	w.WriteByte('\n')
+}
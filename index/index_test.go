@@ -0,0 +1,102 @@
+package index
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bobg/bib"
+)
+
+func TestBuild(t *testing.T) {
+	var b Builder
+	b.Add("Apple", "", "14")
+	b.Add("Apple", "", "3")
+	b.Add("Apple", "varieties of", "5")
+	b.Add("Apple", "", "xii")
+	b.Add("Apple", "", "14")
+	b.Add("Apple", "in cooking", "22–24")
+	b.Add(" The Apple Cart ", "", "101")
+	b.Add("Banana", "", "9n")
+	b.Add("Banana", "", "9")
+	b.SeeAlso("Fruit", "Banana")
+	b.SeeAlso("Fruit", "Apple")
+	b.Add("Fruit", "", "2")
+	b.See("Pomme", "Apple")
+	b.Add("", "", "99")
+
+	buf := new(bytes.Buffer)
+	if err := Write(buf, b.Build()); err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `Apple, xii, 3, 14
+    in cooking, 22–24
+    varieties of, 5
+The Apple Cart, 101
+Banana, 9, 9n
+Fruit, 2. See also Apple; Banana
+Pomme. See Apple
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestKeyer(t *testing.T) {
+	b := Builder{Keyer: bib.NewKeyer(bib.WithAliases(map[string]string{"St.": "Saint"}))}
+	b.Add("St. Louis", "", "1")
+	b.Add("Salem", "", "2")
+	b.Add("Santa Fe", "", "3")
+
+	var got []string
+	for _, h := range b.Build() {
+		got = append(got, h.Text)
+	}
+	want := []string{"St. Louis", "Salem", "Santa Fe"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestCMOS(t *testing.T) {
+	k, err := bib.PresetKeyer("cmos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	subk, err := bib.PresetKeyer("cmos-subentry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := Builder{Keyer: k, SubKeyer: subk}
+	b.Add("New York", "in literature", "10")
+	b.Add("New York", "of the Dutch", "11")
+	b.Add("New York", "elections", "12")
+	b.Add("Newark", "", "20")
+	b.Add("McAdam, John", "", "30")
+	b.Add("Macbeth", "", "31")
+	b.Add("1984 (Orwell)", "", "40")
+
+	buf := new(bytes.Buffer)
+	if err := Write(buf, b.Build()); err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `Macbeth, 31
+McAdam, John, 30
+Newark, 20
+New York
+    of the Dutch, 11
+    elections, 12
+    in literature, 10
+1984 (Orwell), 40
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
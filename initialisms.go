@@ -0,0 +1,54 @@
+package bib
+
+import (
+	"regexp"
+	"strings"
+)
+
+// InitialismPolicy says how a [Keyer] treats initialisms and stylized titles
+// written as single letters separated by periods or asterisks,
+// like "U.S.A.," "M*A*S*H," and "S.H.I.E.L.D."
+type InitialismPolicy int
+
+const (
+	// InitialismsJoined files an initialism as one word made of its letters:
+	// "U.S.A." files as "usa."
+	// This is the default.
+	InitialismsJoined InitialismPolicy = iota
+
+	// InitialismsSpaced files an initialism as separate one-letter words:
+	// "U.S.A." files as "u s a,"
+	// bringing it before "ubiquity" and "usage."
+	// The letters are never taken to be an article,
+	// so "A.B.C. Murders" still files under "a."
+	InitialismsSpaced
+
+	// InitialismsAsWritten files an initialism as written,
+	// separators included:
+	// "U.S.A." files as "u.s.a."
+	InitialismsAsWritten
+)
+
+// WithInitialisms sets the policy for initialisms.
+// The default is [InitialismsJoined].
+func WithInitialisms(p InitialismPolicy) Option {
+	return func(c *config) {
+		c.initialisms = p
+	}
+}
+
+// initialism tells whether chunk
+// (a lowercased, whitespace-delimited piece of the input)
+// is an initialism.
+// If so, it returns the initialism as written
+// (without any surrounding quotes, brackets, or trailing punctuation, which are allowed)
+// and its individual letters.
+func initialism(chunk string) (string, []string, bool) {
+	m := initialismRegex.FindStringSubmatch(chunk)
+	if m == nil {
+		return "", nil, false
+	}
+	return m[1], strings.FieldsFunc(m[1], func(r rune) bool { return r == '.' || r == '*' }), true
+}
+
+var initialismRegex = regexp.MustCompile(`^["'(\[]*(\pL(?:[.*]\pL)+[.*]?)["')\],;:!?]*$`)
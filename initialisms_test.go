@@ -0,0 +1,51 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestInitialisms(t *testing.T) {
+	cases := []struct {
+		inp    string
+		policy InitialismPolicy
+		want   string
+	}{{
+		inp:    "U.S.A. Today",
+		policy: InitialismsJoined,
+		want:   "usa today",
+	}, {
+		inp:    "U.S.A. Today",
+		policy: InitialismsSpaced,
+		want:   "u s a today",
+	}, {
+		inp:    "U.S.A. Today",
+		policy: InitialismsAsWritten,
+		want:   "u.s.a. today",
+	}, {
+		inp:    "M*A*S*H",
+		policy: InitialismsSpaced,
+		want:   "m a s h",
+	}, {
+		inp:    `Agents of "S.H.I.E.L.D.",`,
+		policy: InitialismsAsWritten,
+		want:   "agents of s.h.i.e.l.d.",
+	}, {
+		inp:    "The A.B.C. Murders",
+		policy: InitialismsSpaced,
+		want:   "a b c murders",
+	}, {
+		inp:    "Mr. Smith",
+		policy: InitialismsSpaced,
+		want:   "mr smith",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(WithInitialisms(tc.policy)).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`input "%s", policy %d, got "%s", want "%s"`, tc.inp, tc.policy, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,372 @@
+// Package audiotag reads the title tag from audio files.
+//
+// It understands ID3v2 and ID3v1 (MP3),
+// Vorbis comments (FLAC, Ogg Vorbis, Ogg Opus),
+// and iTunes-style metadata (MP4/M4A).
+// Only as much of each format is parsed as is needed to find the title.
+package audiotag
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+// Title returns the title tag of the audio file in r.
+// It returns "" and no error if the file has no title tag
+// or is in an unrecognized format.
+func Title(r io.ReadSeeker) (string, error) {
+	var magic [8]byte
+	n, err := io.ReadFull(r, magic[:])
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		if errors.Is(err, io.EOF) {
+			return "", nil
+		}
+		return "", err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	var title string
+	switch {
+	case n >= 3 && string(magic[:3]) == "ID3":
+		title, err = id3v2Title(r)
+	case n >= 4 && string(magic[:4]) == "fLaC":
+		title, err = flacTitle(r)
+	case n >= 4 && string(magic[:4]) == "OggS":
+		title, err = oggTitle(r)
+	case n >= 8 && string(magic[4:8]) == "ftyp":
+		title, err = mp4Title(r)
+	}
+	if err != nil || title != "" {
+		return title, err
+	}
+
+	// MP3s may have an ID3v1 tag at the end instead of (or in addition to) an ID3v2 tag.
+	return id3v1Title(r)
+}
+
+func id3v2Title(r io.Reader) (string, error) {
+	var hdr [10]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return "", fmt.Errorf("reading ID3v2 header: %w", err)
+	}
+	version, flags := hdr[3], hdr[5]
+	tag := make([]byte, syncsafe(hdr[6:10]))
+	if _, err := io.ReadFull(r, tag); err != nil {
+		return "", fmt.Errorf("reading ID3v2 tag: %w", err)
+	}
+
+	if flags&0x40 != 0 && version >= 3 && len(tag) >= 4 {
+		// Skip the extended header.
+		size := int(binary.BigEndian.Uint32(tag))
+		if version == 3 {
+			size += 4 // the v2.3 size excludes itself
+		} else {
+			size = syncsafe(tag[:4])
+		}
+		if size > len(tag) {
+			return "", nil
+		}
+		tag = tag[size:]
+	}
+
+	idLen, hdrLen, titleID := 4, 10, "TIT2"
+	if version == 2 {
+		idLen, hdrLen, titleID = 3, 6, "TT2"
+	}
+	for len(tag) >= hdrLen && tag[0] != 0 {
+		id := string(tag[:idLen])
+
+		var size int
+		switch version {
+		case 2:
+			size = int(tag[3])<<16 | int(tag[4])<<8 | int(tag[5])
+		case 3:
+			size = int(binary.BigEndian.Uint32(tag[4:8]))
+		default:
+			size = syncsafe(tag[4:8])
+		}
+		if size > len(tag)-hdrLen {
+			break
+		}
+
+		if id == titleID {
+			return decodeID3Text(tag[hdrLen : hdrLen+size]), nil
+		}
+		tag = tag[hdrLen+size:]
+	}
+	return "", nil
+}
+
+func syncsafe(b []byte) int {
+	return int(b[0]&0x7f)<<21 | int(b[1]&0x7f)<<14 | int(b[2]&0x7f)<<7 | int(b[3]&0x7f)
+}
+
+// decodeID3Text decodes the content of an ID3v2 text frame:
+// an encoding byte followed by the text.
+func decodeID3Text(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	enc, b := b[0], b[1:]
+
+	var s string
+	switch enc {
+	case 0: // ISO-8859-1
+		runes := make([]rune, len(b))
+		for i, c := range b {
+			runes[i] = rune(c)
+		}
+		s = string(runes)
+
+	case 1, 2: // UTF-16 with BOM, UTF-16BE
+		bigEndian := enc == 2
+		if len(b) >= 2 {
+			switch {
+			case b[0] == 0xfe && b[1] == 0xff:
+				bigEndian, b = true, b[2:]
+			case b[0] == 0xff && b[1] == 0xfe:
+				bigEndian, b = false, b[2:]
+			}
+		}
+		u := make([]uint16, len(b)/2)
+		for i := range u {
+			if bigEndian {
+				u[i] = binary.BigEndian.Uint16(b[2*i:])
+			} else {
+				u[i] = binary.LittleEndian.Uint16(b[2*i:])
+			}
+		}
+		s = string(utf16.Decode(u))
+
+	default: // UTF-8
+		s = string(b)
+	}
+
+	// Text frames may hold several null-separated values; the first is the title.
+	if i := strings.IndexByte(s, 0); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
+
+func id3v1Title(r io.ReadSeeker) (string, error) {
+	if _, err := r.Seek(-128, io.SeekEnd); err != nil {
+		// Too short to have an ID3v1 tag.
+		return "", nil
+	}
+	var tag [128]byte
+	if _, err := io.ReadFull(r, tag[:]); err != nil {
+		return "", fmt.Errorf("reading ID3v1 tag: %w", err)
+	}
+	if string(tag[:3]) != "TAG" {
+		return "", nil
+	}
+	return decodeID3Text(append([]byte{0}, bytes.TrimRight(tag[3:33], "\x00 ")...)), nil
+}
+
+func flacTitle(r io.Reader) (string, error) {
+	if _, err := io.ReadFull(r, make([]byte, 4)); err != nil {
+		return "", err
+	}
+	for {
+		var hdr [4]byte
+		if _, err := io.ReadFull(r, hdr[:]); err != nil {
+			return "", fmt.Errorf("reading FLAC metadata block header: %w", err)
+		}
+		var (
+			last  = hdr[0]&0x80 != 0
+			typ   = hdr[0] & 0x7f
+			block = make([]byte, int(hdr[1])<<16|int(hdr[2])<<8|int(hdr[3]))
+		)
+		if _, err := io.ReadFull(r, block); err != nil {
+			return "", fmt.Errorf("reading FLAC metadata block: %w", err)
+		}
+		if typ == 4 {
+			return vorbisCommentTitle(block), nil
+		}
+		if last {
+			return "", nil
+		}
+	}
+}
+
+// vorbisCommentTitle finds the TITLE field in a Vorbis comment block
+// (without the framing that precedes it in Ogg streams).
+func vorbisCommentTitle(b []byte) string {
+	next := func() ([]byte, bool) {
+		if len(b) < 4 {
+			return nil, false
+		}
+		n := int(binary.LittleEndian.Uint32(b))
+		if n > len(b)-4 {
+			return nil, false
+		}
+		s := b[4 : 4+n]
+		b = b[4+n:]
+		return s, true
+	}
+
+	if _, ok := next(); !ok { // vendor string
+		return ""
+	}
+	if len(b) < 4 {
+		return ""
+	}
+	count := int(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	for i := 0; i < count; i++ {
+		c, ok := next()
+		if !ok {
+			return ""
+		}
+		if k, v, ok := strings.Cut(string(c), "="); ok && strings.EqualFold(k, "title") {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+// oggTitle reads the second packet of an Ogg stream,
+// which for Vorbis and Opus is the comment header.
+func oggTitle(r io.Reader) (string, error) {
+	var (
+		packets [][]byte
+		cur     []byte
+	)
+	for len(packets) < 2 {
+		var hdr [27]byte
+		if _, err := io.ReadFull(r, hdr[:]); err != nil {
+			return "", fmt.Errorf("reading Ogg page header: %w", err)
+		}
+		if string(hdr[:4]) != "OggS" {
+			return "", fmt.Errorf("bad Ogg page")
+		}
+		segs := make([]byte, hdr[26])
+		if _, err := io.ReadFull(r, segs); err != nil {
+			return "", fmt.Errorf("reading Ogg segment table: %w", err)
+		}
+		for _, n := range segs {
+			seg := make([]byte, n)
+			if _, err := io.ReadFull(r, seg); err != nil {
+				return "", fmt.Errorf("reading Ogg segment: %w", err)
+			}
+			cur = append(cur, seg...)
+			if n < 255 {
+				packets = append(packets, cur)
+				cur = nil
+			}
+		}
+	}
+
+	p := packets[1]
+	switch {
+	case bytes.HasPrefix(p, []byte("\x03vorbis")):
+		return vorbisCommentTitle(p[7:]), nil
+	case bytes.HasPrefix(p, []byte("OpusTags")):
+		return vorbisCommentTitle(p[8:]), nil
+	}
+	return "", nil
+}
+
+// mp4Title follows the atom path moov/udta/meta/ilst/©nam/data.
+func mp4Title(r io.ReadSeeker) (string, error) {
+	path := []string{"moov", "udta", "meta", "ilst", "\xa9nam", "data"}
+
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return "", err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	for len(path) > 0 {
+		size, typ, err := findAtom(r, end, path[0])
+		if err != nil || typ == "" {
+			return "", err
+		}
+		pos, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return "", err
+		}
+		end = pos + size
+
+		switch path[0] {
+		case "meta":
+			// The meta atom is a "full box" with four bytes of version and flags.
+			if _, err := r.Seek(4, io.SeekCurrent); err != nil {
+				return "", err
+			}
+		case "data":
+			// Type indicator and locale.
+			if size < 8 {
+				return "", nil
+			}
+			buf := make([]byte, size-8)
+			if _, err := r.Seek(8, io.SeekCurrent); err != nil {
+				return "", err
+			}
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return "", fmt.Errorf("reading MP4 title: %w", err)
+			}
+			return strings.TrimSpace(string(buf)), nil
+		}
+		path = path[1:]
+	}
+	return "", nil
+}
+
+// findAtom scans sibling atoms from the current position up to end
+// for one of the given type.
+// On success it leaves r positioned at the start of the atom's content
+// and returns the content size.
+// It returns an empty type if no such atom is found.
+func findAtom(r io.ReadSeeker, end int64, want string) (int64, string, error) {
+	for {
+		pos, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, "", err
+		}
+		if pos+8 > end {
+			return 0, "", nil
+		}
+
+		var hdr [8]byte
+		if _, err := io.ReadFull(r, hdr[:]); err != nil {
+			return 0, "", fmt.Errorf("reading MP4 atom header: %w", err)
+		}
+		var (
+			size    = int64(binary.BigEndian.Uint32(hdr[:4]))
+			typ     = string(hdr[4:])
+			hdrSize = int64(8)
+		)
+		switch size {
+		case 0:
+			size = end - pos
+		case 1:
+			var ext [8]byte
+			if _, err := io.ReadFull(r, ext[:]); err != nil {
+				return 0, "", fmt.Errorf("reading MP4 atom size: %w", err)
+			}
+			size = int64(binary.BigEndian.Uint64(ext[:]))
+			hdrSize = 16
+		}
+		if size < hdrSize || pos+size > end {
+			return 0, "", nil
+		}
+
+		if typ == want {
+			return size - hdrSize, typ, nil
+		}
+		if _, err := r.Seek(pos+size, io.SeekStart); err != nil {
+			return 0, "", err
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package audiotag
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestTitle(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+		want string
+	}{{
+		name: "id3v2.3",
+		data: id3v2(3, "TIT2", append([]byte{0}, "The Gumball Rally"...)),
+		want: "The Gumball Rally",
+	}, {
+		name: "id3v2.4 utf-16",
+		data: id3v2(4, "TIT2", []byte{1, 0xff, 0xfe, '4', 0, '2', 0, 'n', 0, 'd', 0}),
+		want: "42nd",
+	}, {
+		name: "id3v2.2",
+		data: id3v2(2, "TT2", append([]byte{3}, "Straße"...)),
+		want: "Straße",
+	}, {
+		name: "id3v1",
+		data: id3v1("An Apple a Day"),
+		want: "An Apple a Day",
+	}, {
+		name: "flac",
+		data: flac(vorbisComment("ARTIST=Someone", "title=Zoo Station")),
+		want: "Zoo Station",
+	}, {
+		name: "ogg vorbis",
+		data: ogg([]byte("\x01vorbis-ident"), append([]byte("\x03vorbis"), vorbisComment("TITLE=Nine to Five")...)),
+		want: "Nine to Five",
+	}, {
+		name: "ogg opus",
+		data: ogg([]byte("OpusHead"), append([]byte("OpusTags"), vorbisComment("TITLE=Ode")...)),
+		want: "Ode",
+	}, {
+		name: "mp4",
+		data: mp4("The 40-Year-Old Virgin"),
+		want: "The 40-Year-Old Virgin",
+	}, {
+		name: "unknown",
+		data: []byte("just some bytes"),
+		want: "",
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Title(bytes.NewReader(tc.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
+
+func id3v2(version byte, id string, content []byte) []byte {
+	var frame []byte
+	frame = append(frame, id...)
+	switch version {
+	case 2:
+		frame = append(frame, byte(len(content)>>16), byte(len(content)>>8), byte(len(content)))
+	case 3:
+		frame = binary.BigEndian.AppendUint32(frame, uint32(len(content)))
+		frame = append(frame, 0, 0)
+	default:
+		frame = append(frame, toSyncsafe(len(content))...)
+		frame = append(frame, 0, 0)
+	}
+	frame = append(frame, content...)
+	frame = append(frame, make([]byte, 16)...) // padding
+
+	tag := []byte{'I', 'D', '3', version, 0, 0}
+	tag = append(tag, toSyncsafe(len(frame))...)
+	tag = append(tag, frame...)
+	return append(tag, "audio data"...)
+}
+
+func toSyncsafe(n int) []byte {
+	return []byte{byte(n >> 21 & 0x7f), byte(n >> 14 & 0x7f), byte(n >> 7 & 0x7f), byte(n & 0x7f)}
+}
+
+func id3v1(title string) []byte {
+	tag := make([]byte, 128)
+	copy(tag, "TAG")
+	copy(tag[3:], title)
+	return append([]byte("audio data"), tag...)
+}
+
+func vorbisComment(comments ...string) []byte {
+	b := binary.LittleEndian.AppendUint32(nil, 6)
+	b = append(b, "vendor"...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(comments)))
+	for _, c := range comments {
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(c)))
+		b = append(b, c...)
+	}
+	return b
+}
+
+func flac(comment []byte) []byte {
+	b := []byte("fLaC")
+	b = append(b, 0, 0, 0, 34) // STREAMINFO
+	b = append(b, make([]byte, 34)...)
+	b = append(b, 0x84, byte(len(comment)>>16), byte(len(comment)>>8), byte(len(comment)))
+	return append(b, comment...)
+}
+
+func ogg(packets ...[]byte) []byte {
+	var b []byte
+	for i, p := range packets {
+		hdr := make([]byte, 26)
+		copy(hdr, "OggS")
+		binary.LittleEndian.PutUint32(hdr[18:], uint32(i))
+
+		var segs []byte
+		n := len(p)
+		for ; n >= 255; n -= 255 {
+			segs = append(segs, 255)
+		}
+		segs = append(segs, byte(n))
+
+		b = append(b, hdr...)
+		b = append(b, byte(len(segs)))
+		b = append(b, segs...)
+		b = append(b, p...)
+	}
+	return b
+}
+
+func atom(typ string, content ...[]byte) []byte {
+	body := bytes.Join(content, nil)
+	b := binary.BigEndian.AppendUint32(nil, uint32(8+len(body)))
+	b = append(b, typ...)
+	return append(b, body...)
+}
+
+func mp4(title string) []byte {
+	data := atom("data", []byte{0, 0, 0, 1, 0, 0, 0, 0}, []byte(title))
+	ilst := atom("ilst", atom("\xa9ART", atom("data", []byte{0, 0, 0, 1, 0, 0, 0, 0}, []byte("Artist"))), atom("\xa9nam", data))
+	meta := atom("meta", []byte{0, 0, 0, 0}, atom("hdlr", make([]byte, 25)), ilst)
+	moov := atom("moov", atom("mvhd", make([]byte, 100)), atom("udta", meta))
+	return bytes.Join([][]byte{atom("ftyp", []byte("M4A \x00\x00\x00\x00")), atom("mdat", []byte("audio")), moov}, nil)
+}
@@ -0,0 +1,24 @@
+// Package xmlsplice replaces byte ranges of a document,
+// such as the elements of an XML document located with [encoding/xml.Decoder.InputOffset],
+// leaving the rest of the document exactly as it was.
+package xmlsplice
+
+import "bytes"
+
+// Splice returns a copy of src
+// in which the byte range slots[i] is replaced with parts[i].
+// The slots must be in order and must not overlap,
+// and there must be as many parts as slots.
+func Splice(src []byte, slots [][2]int, parts [][]byte) []byte {
+	var (
+		buf  bytes.Buffer
+		prev int
+	)
+	for i, slot := range slots {
+		buf.Write(src[prev:slot[0]])
+		buf.Write(parts[i])
+		prev = slot[1]
+	}
+	buf.Write(src[prev:])
+	return buf.Bytes()
+}
@@ -0,0 +1,11 @@
+package xmlsplice
+
+import "testing"
+
+func TestSplice(t *testing.T) {
+	src := []byte("<a><b>1</b> <b>2</b><c/></a>")
+	got := Splice(src, [][2]int{{3, 11}, {12, 20}}, [][]byte{[]byte("<b>2</b>"), []byte("<b>one</b>")})
+	if want := "<a><b>2</b> <b>one</b><c/></a>"; string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
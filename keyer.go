@@ -0,0 +1,522 @@
+package bib
+
+import (
+	"maps"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bobg/go-generics/v4/slices"
+)
+
+// Keyer produces bibliographic sort keys
+// according to a set of options,
+// and compares and sorts strings by those keys.
+// The options are processed once, when the Keyer is made,
+// and a Keyer is safe for concurrent use.
+type Keyer struct {
+	cfg config
+
+	// aliases is the compiled form of cfg.aliases.
+	aliases map[string][]alias
+}
+
+// Collator is another name for [Keyer],
+// for those who know the concept by that name.
+type Collator = Keyer
+
+// NewCollator is the same as [NewKeyer].
+func NewCollator(opts ...Option) *Collator {
+	return NewKeyer(opts...)
+}
+
+// config holds the settings that Options control.
+type config struct {
+	maxBytes      int
+	initialisms   InitialismPolicy
+	alphanumerics AlphanumericPolicy
+	stripMarkup   bool
+	aliases       map[string]string
+	resolver      func(string) string
+	numbering     NumberingSystem
+	hangul        HangulPolicy
+	segmenter     Segmenter
+
+	symbolBucket *string
+	spellSymbols bool
+	spellPercent bool
+	placement    Placement
+	dimensions   bool
+	versions     bool
+
+	letterByLetter bool
+	subentry       bool
+
+	parallel       bool
+	chooseParallel func([]string) int
+
+	tieBreak func(a, b string) int
+
+	articles          map[string]bool // nil means the default articles
+	oneLetterInverted bool
+	numberMode        NumberMode
+	decimalComma      bool
+	negative          string // the word for a minus sign
+	currencies        map[string]Currency
+	noClockTimes      bool
+	eras              bool
+	noYears           bool
+	yearRanges        []YearRange // nil means the default ranges
+	ohYears           bool
+	ordinal           bool // for NumberWords
+	unhyphenated      bool // for NumberWords
+	padWidth          int
+	britishAnd        bool
+
+	progress func(Progress)
+}
+
+// Option is the type of an option that can be passed to [NewKeyer].
+type Option func(*config)
+
+// NewKeyer creates a new [Keyer] with the given options.
+// With no options,
+// the Keyer produces the same keys as [Key].
+func NewKeyer(opts ...Option) *Keyer {
+	return newKeyer(config{}, opts)
+}
+
+// With returns a new [Keyer] with the options of k
+// plus the given ones,
+// which take precedence.
+func (k *Keyer) With(opts ...Option) *Keyer {
+	cfg := k.cfg
+	cfg.aliases = maps.Clone(cfg.aliases)
+	cfg.currencies = maps.Clone(cfg.currencies)
+	return newKeyer(cfg, opts)
+}
+
+func newKeyer(cfg config, opts []Option) *Keyer {
+	k := &Keyer{cfg: cfg}
+	for _, opt := range opts {
+		opt(&k.cfg)
+	}
+	if len(k.cfg.aliases) > 0 {
+		k.aliases = compileAliases(k.cfg)
+	}
+	return k
+}
+
+var defaultKeyer atomic.Pointer[Keyer]
+
+func init() {
+	defaultKeyer.Store(NewKeyer())
+}
+
+// Default returns the default [Keyer],
+// used by the package-level functions such as [Key], [Less], and [Sort].
+func Default() *Keyer {
+	return defaultKeyer.Load()
+}
+
+// SetDefault replaces the default [Keyer]
+// with one constructed from the given options,
+// so that an application can configure the behavior of [Key], [Less], [Sort], etc.
+// in one place.
+// The options replace, rather than add to, those of the previous default.
+// Calling SetDefault with no options restores the original behavior.
+//
+// SetDefault is safe to call concurrently with other functions in this package,
+// each of which uses either the old or the new default throughout.
+// But note that keys computed before and after a change in the default
+// may not be comparable.
+func SetDefault(opts ...Option) {
+	defaultKeyer.Store(NewKeyer(opts...))
+}
+
+// WithMaxBytes limits keys to at most n bytes,
+// e.g. to fit a database column of limited width.
+// A value of zero or less means no limit.
+//
+// A key that is too long is cut at the last word boundary that fits,
+// or, if even its first word is too long,
+// at the last UTF-8 rune boundary that fits.
+// Either way the result is valid UTF-8.
+//
+// Truncation means that different keys may become equal.
+// Also, because the cut falls at a word boundary,
+// a truncated key can sort before a shorter, untruncated key that shares its prefix,
+// which the full keys would not.
+// Applications needing a total, exact order
+// should store something in addition to the truncated key
+// (the full key or the original string)
+// and use it as a secondary sort field to break ties,
+// e.g. ORDER BY sort_key, title.
+func WithMaxBytes(n int) Option {
+	return func(c *config) {
+		c.maxBytes = n
+	}
+}
+
+// Key converts an input string to a bibliographic sort key.
+// Input with nothing to file on,
+// such as "" or "!!!",
+// gets a key that sorts first
+// (or last; see [WithPlacement] and [WithSymbolBucket]).
+func (k *Keyer) Key(s string) string {
+	key, _ := k.key(s)
+	return key
+}
+
+// keyInfo records which transformations produced a key.
+type keyInfo struct {
+	articleStripped bool
+	numberConverted bool
+	unfiled         bool // the input had nothing to file on
+}
+
+func (k *Keyer) key(s string) (string, keyInfo) {
+	return k.keySpans(s, nil, nil)
+}
+
+// keySpans computes the key of s,
+// recording in spans, if it is not nil,
+// where each word of the key came from.
+func (k *Keyer) keySpans(s string, spans *[]Span, nums *[]NumberSpan) (string, keyInfo) {
+	var info keyInfo
+
+	s = k.prepare(s)
+	toks := k.split(s)
+	if len(toks) == 0 {
+		info.unfiled = true
+		if strings.TrimSpace(s) == "" {
+			return k.emptyKey(), info
+		}
+		return k.truncate(k.symbolKey(s)), info
+	}
+	if len(toks) > 1 && k.isArticle(toks[0]) {
+		toks = toks[1:]
+		info.articleStripped = true
+	} else if n := len(toks); n > 1 && k.isInvertedArticle(s, toks[n-2], toks[n-1]) {
+		toks = toks[:n-1]
+		info.articleStripped = true
+	}
+	if k.cfg.subentry {
+		for len(toks) > 1 && !toks[0].initialism && subentryWords[toks[0].text] {
+			toks = toks[1:]
+		}
+	}
+
+	f := slices.Map(toks, func(t token) string { return t.text })
+	lead := 1 // the number of words that replace the first token
+	if g, n, ok := k.leadingNumber(s, toks, f, nums); ok {
+		// The first n tokens are now one.
+		end := toks[n-1].end
+		toks = append(toks[:1:1], toks[n:]...)
+		toks[0].end = end
+		lead = len(g) - len(f) + n
+		f = g
+		info.numberConverted = true
+		if k.cfg.numberMode == NumbersLast {
+			f[0] = numbersLastPrefix + f[0]
+		}
+	}
+	if k.cfg.numberMode == NumbersAllByValue {
+		k.encodeNumbers(f[lead:])
+	}
+
+	key := k.join(s, toks, f, lead, spans)
+	hkey := k.applyHangul(key)
+	tkey := k.truncate(hkey)
+	if spans != nil {
+		adjustSpans(spans, len(hkey)-len(key), len(tkey))
+	}
+	return tkey, info
+}
+
+// join joins the words of a key,
+// which come from toks (taken from s),
+// recording in spans, if it is not nil,
+// where each word came from.
+// The first lead words all come from the first token;
+// the rest correspond one-to-one with the remaining tokens.
+//
+// The words are separated by spaces,
+// or, with [WithLetterByLetter],
+// joined without spaces (or the hyphens in spelled-out numbers)
+// except after a token with a break after it.
+func (k *Keyer) join(s string, toks []token, words []string, lead int, spans *[]Span) string {
+	var breaks []bool
+	if k.cfg.letterByLetter {
+		breaks = wordBreaks(s, toks)
+	}
+
+	var b strings.Builder
+	for j, w := range words {
+		t := max(0, j-lead+1) // the token w came from
+		if k.cfg.letterByLetter {
+			w = strings.ReplaceAll(w, "-", "")
+		} else if j > 0 {
+			b.WriteByte(' ')
+		}
+		start := b.Len()
+		b.WriteString(w)
+		if spans != nil {
+			*spans = append(*spans, Span{KeyStart: start, KeyEnd: b.Len(), Start: toks[t].start, End: toks[t].end})
+		}
+		if k.cfg.letterByLetter && j >= lead-1 && j < len(words)-1 && breaks[t] {
+			b.WriteByte(' ')
+		}
+	}
+	return b.String()
+}
+
+// isInvertedArticle tells whether last is an article moved to the end of s,
+// following a comma after prev,
+// as in "Hobbit, The."
+// A single-letter article counts only with [WithOneLetterInvertedArticles],
+// and even then a single letter with a period is taken to be an initial,
+// as in "Smith, A."
+func (k *Keyer) isInvertedArticle(s string, prev, last token) bool {
+	if last.start == prev.start || s[prev.end-1] != ',' || !k.isArticle(last) {
+		return false
+	}
+	if utf8.RuneCountInString(last.text) > 1 {
+		return true
+	}
+	if !k.cfg.oneLetterInverted {
+		return false
+	}
+	chunk := s[last.start:last.end]
+	return !(utf8.RuneCountInString(chunk) == 2 && strings.HasSuffix(chunk, "."))
+}
+
+// Compare returns -1, 0, or 1
+// according to whether a sorts before, the same as, or after b.
+// See [Compare].
+func (k *Keyer) Compare(a, b string) int {
+	c := k.compareKeyed(KeyedString{Orig: a, Key: k.Key(a)}, KeyedString{Orig: b, Key: k.Key(b)})
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return 1
+	}
+	return 0
+}
+
+// Equal tells whether a and b have the same key.
+func (k *Keyer) Equal(a, b string) bool {
+	return k.Key(a) == k.Key(b)
+}
+
+// Less tells whether a sorts before b.
+func (k *Keyer) Less(a, b string) bool {
+	return k.Compare(a, b) < 0
+}
+
+// Sort sorts strs by their keys.
+func (k *Keyer) Sort(strs []string) {
+	// We could just write:
+	//
+	//   sort.Slice(strs, func(i, j int) bool { return k.Less(strs[i], strs[j]) })
+	//
+	// but that would call Key on each string in strs more than once, on average,
+	// which is inefficient.
+	// So instead we compute keys for all the strings exactly once into a new slice,
+	// then sort the strings and keys together.
+
+	if k.sortWithProgress(strs) {
+		return
+	}
+	sort.Sort(keyedSorter[string]{k: k, items: strs, keyed: k.MakeKeyed(strs)})
+}
+
+// token is one word of a key.
+type token struct {
+	text string
+
+	// start and end are the byte offsets in the input
+	// of the whitespace-delimited chunk that the token came from.
+	start, end int
+
+	// initialism is true for the letters of an initialism
+	// that is not joined into a single token.
+	initialism bool
+
+	// marked is true for an article marked as ignorable,
+	// as in "(The) Gumball Rally."
+	marked bool
+}
+
+func (k *Keyer) isArticle(t token) bool {
+	if t.marked {
+		return true
+	}
+	if t.initialism {
+		return false
+	}
+	if k.cfg.articles != nil {
+		return k.cfg.articles[t.text]
+	}
+	return slices.Contains(defaultArticles, t.text)
+}
+
+// defaultArticles are the articles that a Keyer ignores without [WithArticles].
+var defaultArticles = []string{"a", "an", "the"}
+
+var markedArticleRegex = regexp.MustCompile(`^\s*[(\[]((?i:the|an|a))[)\]]`)
+
+// tokens normalizes s and splits it into tokens.
+// With [WithResolver], [WithMarkupStripping], or [WithParallelTitles],
+// the tokens' offsets are into the string that prepare returns.
+func (k *Keyer) tokens(s string) []token {
+	return k.split(k.prepare(s))
+}
+
+// prepare applies the resolver, markup stripping, and parallel-title selection, if any, to s.
+func (k *Keyer) prepare(s string) string {
+	if k.cfg.resolver != nil {
+		s = k.cfg.resolver(s)
+	}
+	if k.cfg.stripMarkup {
+		s = stripMarkup(s)
+	}
+	if k.cfg.parallel {
+		s = k.parallelTitle(s)
+	}
+	return s
+}
+
+// split splits s, which has already been prepared, into tokens.
+func (k *Keyer) split(s string) []token {
+	var (
+		toks  []token
+		start int
+	)
+
+	// Some catalogs mark a leading article as ignorable with parentheses or brackets,
+	// as in "(The) Gumball Rally."
+	// Such an article is a token of its own
+	// even when the title follows it without a space.
+	if m := markedArticleRegex.FindStringSubmatchIndex(s); m != nil {
+		toks = append(toks, token{text: strings.ToLower(s[m[2]:m[3]]), start: m[2] - 1, end: m[1], marked: true})
+		start = m[1]
+	}
+
+	for start < len(s) {
+		r, size := utf8.DecodeRuneInString(s[start:])
+		if unicode.IsSpace(r) {
+			start += size
+			continue
+		}
+		end := start + size
+		for end < len(s) {
+			r, size := utf8.DecodeRuneInString(s[end:])
+			if unicode.IsSpace(r) {
+				break
+			}
+			end += size
+		}
+		toks = k.appendChunk(toks, s[start:end], start, end)
+		start = end
+	}
+	if k.aliases != nil {
+		toks = k.expandAliases(toks)
+	}
+	return toks
+}
+
+// appendChunk normalizes a whitespace-delimited chunk of the input,
+// which begins and ends at the given byte offsets,
+// and appends the resulting tokens to toks.
+func (k *Keyer) appendChunk(toks []token, chunk string, start, end int) []token {
+	chunk = strings.ToLower(chunk)
+
+	if k.cfg.initialisms != InitialismsJoined {
+		if written, letters, ok := initialism(chunk); ok {
+			if k.cfg.initialisms == InitialismsAsWritten {
+				return append(toks, token{text: written, start: start, end: end})
+			}
+			for _, l := range letters {
+				toks = append(toks, token{text: l, start: start, end: end, initialism: true})
+			}
+			return toks
+		}
+	}
+
+	if k.cfg.dimensions {
+		if words, ok := k.dimension(chunk); ok {
+			for _, w := range words {
+				toks = append(toks, token{text: w, start: start, end: end})
+			}
+			return toks
+		}
+	}
+
+	if k.cfg.versions {
+		if words, ok := k.version(chunk); ok {
+			for _, w := range words {
+				toks = append(toks, token{text: w, start: start, end: end})
+			}
+			return toks
+		}
+	}
+
+	if k.cfg.numberMode == NumbersAllByValue {
+		// Keep the decimal point for encodeNumbers.
+		if whole, frac, ok := k.decimalParts(trimChunk(chunk)); ok {
+			return append(toks, token{text: whole + "." + frac, start: start, end: end})
+		}
+	}
+
+	chunk = strings.ReplaceAll(chunk, "&", " and ")
+	if k.cfg.spellPercent {
+		chunk = strings.ReplaceAll(chunk, "%", " percent ")
+	}
+	chunk = normalizeGraphemes(chunk)
+	for _, field := range strings.Fields(chunk) {
+		for _, w := range k.segment(field) {
+			if k.cfg.hangul == HangulRomanized {
+				w = romanizeHangul(w)
+			}
+			if k.cfg.alphanumerics == AlphanumericsSpelled {
+				if parts, ok := k.alphanumeric(w); ok {
+					for _, p := range parts {
+						toks = append(toks, token{text: p, start: start, end: end})
+					}
+					continue
+				}
+			}
+			toks = append(toks, token{text: w, start: start, end: end})
+		}
+	}
+	return toks
+}
+
+// words normalizes s and splits it into words.
+func words(s string) []string {
+	return slices.Map(Default().tokens(s), func(t token) string { return t.text })
+}
+
+// truncate applies the WithMaxBytes option.
+func (k *Keyer) truncate(key string) string {
+	n := k.cfg.maxBytes
+	if n <= 0 || len(key) <= n {
+		return key
+	}
+	if key[n] == ' ' {
+		// Cutting here loses only whole words.
+		return key[:n]
+	}
+	for n > 0 && !utf8.RuneStart(key[n]) {
+		n--
+	}
+	if i := strings.LastIndexByte(key[:n], ' '); i > 0 {
+		return key[:i]
+	}
+	return key[:n]
+}
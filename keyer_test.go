@@ -0,0 +1,252 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMaxBytes(t *testing.T) {
+	cases := []struct {
+		inp  string
+		n    int
+		want string
+	}{{
+		inp:  "The Gumball Rally",
+		n:    0,
+		want: "gumball rally",
+	}, {
+		inp:  "The Gumball Rally",
+		n:    13,
+		want: "gumball rally",
+	}, {
+		inp:  "The Gumball Rally",
+		n:    12,
+		want: "gumball",
+	}, {
+		inp:  "The Gumball Rally",
+		n:    7,
+		want: "gumball",
+	}, {
+		inp:  "The Gumball Rally",
+		n:    5,
+		want: "gumba",
+	}, {
+		inp:  "Żółw",
+		n:    4,
+		want: "żó",
+	}, {
+		inp:  "Żółw",
+		n:    5,
+		want: "żó",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(WithMaxBytes(tc.n)).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`input "%s", max %d, got "%s", want "%s"`, tc.inp, tc.n, got, tc.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf(`got invalid UTF-8 "%s"`, got)
+			}
+		})
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	defer SetDefault()
+
+	SetDefault(WithMaxBytes(7))
+	if got := Key("The Gumball Rally"); got != "gumball" {
+		t.Errorf(`with default max bytes 7, got "%s", want "gumball"`, got)
+	}
+
+	SetDefault()
+	if got := Key("The Gumball Rally"); got != "gumball rally" {
+		t.Errorf(`after restoring default, got "%s", want "gumball rally"`, got)
+	}
+}
+
+func TestMarkedArticle(t *testing.T) {
+	cases := []struct {
+		inp  string
+		want string
+	}{{
+		inp:  "(The) Gumball Rally",
+		want: "gumball rally",
+	}, {
+		inp:  "(The)Gumball Rally",
+		want: "gumball rally",
+	}, {
+		inp:  "[A] Day in the Life",
+		want: "day in the life",
+	}, {
+		inp:  "  (an) Apple",
+		want: "apple",
+	}, {
+		inp:  "(The)",
+		want: "the",
+	}, {
+		inp:  "(Theory) of Everything",
+		want: "theory of everything",
+	}, {
+		inp:  "The (Real) Story",
+		want: "real story",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got := Key(tc.inp); got != tc.want {
+				t.Errorf(`input "%s", got "%s", want "%s"`, tc.inp, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDecades(t *testing.T) {
+	cases := []struct {
+		inp  string
+		want string
+	}{{
+		inp:  "The 1960s",
+		want: "nineteen sixties",
+	}, {
+		inp:  "1990s Hits",
+		want: "nineteen nineties hits",
+	}, {
+		inp:  "'60s Soul",
+		want: "sixties soul",
+	}, {
+		inp:  "The ’90s",
+		want: "nineties",
+	}, {
+		inp:  "1980's Movies",
+		want: "nineteen eighties movies",
+	}, {
+		inp:  "80s Rock",
+		want: "eighties rock",
+	}, {
+		inp:  "The 1900s",
+		want: "nineteen hundreds",
+	}, {
+		inp:  "2000s Pop",
+		want: "two thousands pop",
+	}, {
+		inp:  "2010s",
+		want: "twenty tens",
+	}, {
+		inp:  "1800s Literature",
+		want: "eighteen hundreds literature",
+	}, {
+		inp:  "1961s",
+		want: "1961s",
+	}, {
+		inp:  "Hits of the 1990s",
+		want: "hits of the 1990s",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got := Key(tc.inp); got != tc.want {
+				t.Errorf(`input "%s", got "%s", want "%s"`, tc.inp, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWith(t *testing.T) {
+	k := NewKeyer(WithAliases(map[string]string{"St.": "Saint"}))
+	k2 := k.With(WithAliases(map[string]string{"Mt.": "Mount"}), WithNumberMode(NumbersAsWritten))
+
+	if got, want := k2.Key("St. Helens, Mt."), "saint helens mount"; got != want {
+		t.Errorf(`got "%s", want "%s"`, got, want)
+	}
+	if got, want := k2.Key("101 Dalmatians"), "101 dalmatians"; got != want {
+		t.Errorf(`got "%s", want "%s"`, got, want)
+	}
+
+	// The original is unchanged.
+	if got, want := k.Key("St. Helens, Mt."), "saint helens mt"; got != want {
+		t.Errorf(`got "%s" from the original, want "%s"`, got, want)
+	}
+	if got, want := k.Key("101 Dalmatians"), "one hundred one dalmatians"; got != want {
+		t.Errorf(`got "%s" from the original, want "%s"`, got, want)
+	}
+}
+
+func TestCollator(t *testing.T) {
+	c := NewCollator(WithNumberMode(NumbersByValue))
+
+	x := []string{"Zebra", "The 10 Commandments", "9 Lives", "An Apple"}
+	c.Sort(x)
+	want := []string{"9 Lives", "The 10 Commandments", "An Apple", "Zebra"}
+	for i := range want {
+		if x[i] != want[i] {
+			t.Fatalf("got %q, want %q", x, want)
+		}
+	}
+
+	if !c.Less("9 Lives", "10 Commandments") {
+		t.Error("9 Lives is not less than 10 Commandments")
+	}
+	if got := c.Compare("The Apple", "Apple"); got != 0 {
+		t.Errorf("got %d comparing The Apple with Apple, want 0", got)
+	}
+}
+
+func TestInvertedArticle(t *testing.T) {
+	oneLetter := WithOneLetterInvertedArticles()
+
+	cases := []struct {
+		opts      []Option
+		inp, want string
+	}{{
+		inp:  "Hobbit, The",
+		want: "hobbit",
+	}, {
+		inp:  "Beatles, The",
+		want: "beatles",
+	}, {
+		inp:  "Man Called Ove, A",
+		want: "man called ove a",
+	}, {
+		opts: []Option{oneLetter},
+		inp:  "Man Called Ove, A",
+		want: "man called ove",
+	}, {
+		inp:  "Hobbit The",
+		want: "hobbit the",
+	}, {
+		inp:  "Vitamin A",
+		want: "vitamin a",
+	}, {
+		inp:  "Smith, A.",
+		want: "smith a",
+	}, {
+		opts: []Option{oneLetter},
+		inp:  "Smith, A.",
+		want: "smith a",
+	}, {
+		inp:  "Smith, A",
+		want: "smith a",
+	}, {
+		inp:  "Bang, A",
+		want: "bang a",
+	}, {
+		inp:  "Is It, A?",
+		want: "is it a",
+	}, {
+		inp:  "Officer and a Gentleman, An",
+		want: "officer and a gentleman",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(tc.opts...).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
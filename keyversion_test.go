@@ -0,0 +1,142 @@
+package bib
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/keys.golden")
+
+// goldenInputs are inputs whose keys are recorded in testdata/keys.golden,
+// to detect changes to the key algorithm that are made without changing keyVersion.
+var goldenInputs = []string{
+	"The Gumball Rally",
+	"A Tale of Two Cities",
+	"An Inconvenient Truth",
+	"Hobbit, The",
+	"Man Called Ove, A",
+	"Smith, A",
+	"Smith, A.",
+	"2001: A Space Odyssey",
+	"The 39 Steps",
+	"1984",
+	"42nd Street",
+	"The 1100th Anniversary",
+	"Catch-22",
+	"1914–1918: The Great War",
+	"−40 Degrees",
+	"8½",
+	"8 1/2 Weeks",
+	"$5 a Day",
+	"£10 Poms",
+	"3:10 to Yuma",
+	"AD 1066",
+	"1066 A.D.",
+	"$1,001,984",
+	"1,001,984 Things",
+	"The 12th Man",
+	"9/11",
+	"1/2 Price",
+	"1,000 Places to See Before You Die",
+	"3.14 Reasons",
+	"Ocean's Eleven",
+	"It's a Wonderful Life",
+	"Mc Donald's",
+	"Dr. Strangelove",
+	"E.T. the Extra-Terrestrial",
+	"M*A*S*H",
+	"!!!",
+	"#1 Crush",
+	"Æon Flux",
+	"Les Misérables",
+	"Das Boot",
+	"El Topo",
+	"naïve café",
+	"ΑΒΓ",
+	"Мастер и Маргарита",
+	"東京物語",
+	"한국어",
+	"  ",
+	"",
+	"Star Wars: Episode IV – A New Hope",
+	"<i>Moby-Dick</i>",
+	"Henry VIII",
+	"Henry Ⅷ",
+	"ⅹⅰ",
+	"Louis XIV",
+	"The 4x4 Handbook",
+	"Zoë",
+	"Rock & Roll",
+	"100%",
+	"$5 Shakes",
+}
+
+// TestKeyVersion checks that keyVersion has been incremented
+// if Key produces different output for any of goldenInputs.
+// After incrementing keyVersion,
+// run "go test -run TestKeyVersion -update" to rewrite the golden file.
+func TestKeyVersion(t *testing.T) {
+	const filename = "testdata/keys.golden"
+
+	if *update {
+		var buf strings.Builder
+		fmt.Fprintf(&buf, "%d\n", keyVersion)
+		for _, s := range goldenInputs {
+			fmt.Fprintf(&buf, "%q %q\n", s, Key(s))
+		}
+		if err := os.WriteFile(filename, []byte(buf.String()), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	if !sc.Scan() {
+		t.Fatalf("%s is empty", filename)
+	}
+	version, err := strconv.Atoi(sc.Text())
+	if err != nil {
+		t.Fatalf("parsing version in %s: %s", filename, err)
+	}
+	if version != keyVersion {
+		t.Fatalf("%s is for key version %d, current version is %d; rerun with -update", filename, version, keyVersion)
+	}
+
+	want := make(map[string]string)
+	for sc.Scan() {
+		var inp, key string
+		if _, err := fmt.Sscanf(sc.Text(), "%q %q", &inp, &key); err != nil {
+			t.Fatalf("parsing %s: %s", filename, err)
+		}
+		want[inp] = key
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range goldenInputs {
+		key, ok := want[s]
+		if !ok {
+			t.Errorf("%q is not in %s; rerun with -update", s, filename)
+			continue
+		}
+		if got := Key(s); got != key {
+			t.Errorf("key of %q changed from %q to %q without incrementing keyVersion", s, key, got)
+		}
+	}
+
+	if KeyVersion() != keyVersion {
+		t.Errorf("KeyVersion() is %d, want %d", KeyVersion(), keyVersion)
+	}
+}
@@ -0,0 +1,31 @@
+package bib
+
+// WithLetterByLetter makes a [Keyer] alphabetize letter by letter
+// rather than word by word:
+// spaces between words are ignored,
+// so that "Newark" sorts before "New York"
+// (which word-by-word alphabetizing puts first).
+// As in the Chicago Manual of Style,
+// letter-by-letter alphabetizing stops at a comma or an opening parenthesis,
+// and what follows breaks ties,
+// so that "New, John" still sorts before "Newark."
+func WithLetterByLetter() Option {
+	return func(c *config) {
+		c.letterByLetter = true
+	}
+}
+
+// wordBreaks reports, for each of toks (taken from s),
+// whether a comma or parenthesis follows it.
+func wordBreaks(s string, toks []token) []bool {
+	breaks := make([]bool, len(toks))
+	for i := 0; i < len(toks)-1; i++ {
+		if toks[i+1].start == toks[i].start {
+			continue // same chunk
+		}
+		if s[toks[i].end-1] == ',' || s[toks[i+1].start] == '(' {
+			breaks[i] = true
+		}
+	}
+	return breaks
+}
@@ -0,0 +1,50 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLetterByLetter(t *testing.T) {
+	cases := []struct {
+		inp, want string
+	}{{
+		inp:  "New York",
+		want: "newyork",
+	}, {
+		inp:  "The New-York Historical Society",
+		want: "newyorkhistoricalsociety",
+	}, {
+		inp:  "New, John",
+		want: "new john",
+	}, {
+		inp:  "Mercury (planet)",
+		want: "mercury planet",
+	}, {
+		inp:  "Smith, John, Jr.",
+		want: "smith john jr",
+	}, {
+		inp:  "21 Jump Street",
+		want: "twentyonejumpstreet",
+	}, {
+		inp:  "1920s Fashion",
+		want: "nineteentwentiesfashion",
+	}}
+
+	k := NewKeyer(WithLetterByLetter())
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := k.Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+
+	if !(k.Key("Newark") < k.Key("New York")) {
+		t.Error("Newark does not sort before New York letter by letter")
+	}
+	if !(k.Key("New, John") < k.Key("Newark")) {
+		t.Error("New, John does not sort before Newark letter by letter")
+	}
+}
@@ -0,0 +1,146 @@
+package bib
+
+import (
+	"iter"
+	"sort"
+
+	"github.com/bobg/go-generics/v4/slices"
+)
+
+// List is a list of strings kept in bibliographic order,
+// with the key of each string computed only once,
+// when it is added.
+// Strings whose keys are equal are in the order in which they were added
+// (unless the Keyer has a tie-breaking function; see [WithTieBreak]).
+//
+// The zero List is empty and ready to use,
+// with the default [Keyer] (see [SetDefault]).
+// A List is not safe for concurrent use.
+type List struct {
+	k       *Keyer
+	entries []KeyedString
+}
+
+// NewList produces a [List] containing strs.
+// It uses the default [Keyer] (see [SetDefault]).
+func NewList(strs ...string) *List {
+	return Default().NewList(strs...)
+}
+
+// NewList produces a [List] containing strs
+// and ordered by k.
+func (k *Keyer) NewList(strs ...string) *List {
+	l := &List{k: k, entries: k.MakeKeyed(strs)}
+	k.sortKeyed(l.entries)
+	return l
+}
+
+func (l *List) keyer() *Keyer {
+	if l.k == nil {
+		l.k = Default()
+	}
+	return l.k
+}
+
+// Len tells the number of strings in l.
+func (l *List) Len() int {
+	return len(l.entries)
+}
+
+// At returns the string at position i in l.
+// It panics if i is out of range.
+func (l *List) At(i int) string {
+	return l.entries[i].Orig
+}
+
+// Add adds s to l,
+// after any strings already in l whose keys equal the key of s.
+// It returns the position of s.
+func (l *List) Add(s string) int {
+	var (
+		k = l.keyer()
+		e = KeyedString{Orig: s, Key: k.Key(s)}
+		i = sort.Search(len(l.entries), func(i int) bool { return k.compareKeyed(l.entries[i], e) > 0 })
+	)
+	l.entries = slices.Insert(l.entries, i, e)
+	return i
+}
+
+// Remove removes the first occurrence of s from l
+// and tells whether there was one.
+// Only s itself is removed,
+// not strings with the same key.
+func (l *List) Remove(s string) bool {
+	i, ok := l.index(s)
+	if ok {
+		l.entries = slices.Delete(l.entries, i, i+1)
+	}
+	return ok
+}
+
+// Contains tells whether s is in l.
+// Strings with the same key as s don't count.
+func (l *List) Contains(s string) bool {
+	_, ok := l.index(s)
+	return ok
+}
+
+// index returns the position of the first occurrence of s in l,
+// or false if there is none.
+func (l *List) index(s string) (int, bool) {
+	key := l.keyer().Key(s)
+	i := sort.Search(len(l.entries), func(i int) bool { return l.entries[i].Key >= key })
+	for ; i < len(l.entries) && l.entries[i].Key == key; i++ {
+		if l.entries[i].Orig == s {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// All returns an iterator over the positions and strings in l, in order.
+// The list must not be changed during iteration.
+func (l *List) All() iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		for i, e := range l.entries {
+			if !yield(i, e.Orig) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the strings in l
+// that sort at or after from and before to,
+// as for a "browse titles from A to C" page.
+// Either bound may be "" to leave that end open.
+// The list must not be changed during iteration.
+func (l *List) Range(from, to string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		var (
+			k     = l.keyer()
+			lo    = 0
+			toKey string
+		)
+		if from != "" {
+			fromKey := k.Key(from)
+			lo = sort.Search(len(l.entries), func(i int) bool { return l.entries[i].Key >= fromKey })
+		}
+		if to != "" {
+			toKey = k.Key(to)
+		}
+		for _, e := range l.entries[lo:] {
+			if to != "" && e.Key >= toKey {
+				return
+			}
+			if !yield(e.Orig) {
+				return
+			}
+		}
+	}
+}
+
+// Strings returns a copy of the strings in l, in order.
+func (l *List) Strings() []string {
+	return Origs(l.entries)
+}
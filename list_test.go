@@ -0,0 +1,83 @@
+package bib
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	var l List
+	for _, s := range []string{"Mike", "The Heat", "Zulu", "Alpha", "Heat", "Echo"} {
+		l.Add(s)
+	}
+	if i := l.Add("A Mike"); i != 5 {
+		t.Errorf("added A Mike at %d, want 5", i)
+	}
+
+	want := []string{"Alpha", "Echo", "The Heat", "Heat", "Mike", "A Mike", "Zulu"}
+	if got := l.Strings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if l.Len() != len(want) {
+		t.Errorf("got length %d, want %d", l.Len(), len(want))
+	}
+	for i, s := range l.All() {
+		if got := l.At(i); got != s || got != want[i] {
+			t.Errorf("At(%d) is %q, All yields %q, want %q", i, got, s, want[i])
+		}
+	}
+
+	if !l.Contains("Heat") {
+		t.Error("list does not contain Heat")
+	}
+	if l.Contains("A Heat") {
+		t.Error("list contains A Heat")
+	}
+
+	if l.Remove("A Heat") {
+		t.Error("removed A Heat")
+	}
+	if !l.Remove("Heat") {
+		t.Error("did not remove Heat")
+	}
+	want = []string{"Alpha", "Echo", "The Heat", "Mike", "A Mike", "Zulu"}
+	if got := l.Strings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("after removing, got %q, want %q", got, want)
+	}
+}
+
+func TestListRange(t *testing.T) {
+	l := NewList("Zulu", "The Echo", "Alpha", "Charlie", "Bravo", "Mike", "Delta")
+
+	cases := []struct {
+		from, to string
+		want     []string
+	}{{
+		want: []string{"Alpha", "Bravo", "Charlie", "Delta", "The Echo", "Mike", "Zulu"},
+	}, {
+		from: "B",
+		to:   "E",
+		want: []string{"Bravo", "Charlie", "Delta"},
+	}, {
+		from: "the D",
+		want: []string{"Delta", "The Echo", "Mike", "Zulu"},
+	}, {
+		to:   "Bravo",
+		want: []string{"Alpha"},
+	}, {
+		from: "N",
+		to:   "Y",
+		want: nil,
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := slices.Collect(l.Range(tc.from, tc.to))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
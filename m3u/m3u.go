@@ -0,0 +1,156 @@
+// Package m3u reads, sorts, and writes M3U and M3U8 playlists.
+//
+// Entries are sorted bibliographically by the title in their #EXTINF directive,
+// or by their file name when there is no title.
+// Directives travel with the entry they precede.
+package m3u
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/bobg/go-generics/v4/slices"
+
+	"github.com/bobg/bib"
+)
+
+// Playlist is a parsed M3U playlist.
+type Playlist struct {
+	// Header holds the lines preceding the first entry,
+	// such as #EXTM3U and playlist-wide directives.
+	Header []string
+
+	Entries []*Entry
+
+	// Trailer holds any lines following the last entry's URI.
+	Trailer []string
+}
+
+// Entry is one playlist entry.
+type Entry struct {
+	// Directives are the lines preceding the entry's URI,
+	// including any #EXTINF directive, other comments, and blank lines.
+	Directives []string
+
+	// URI is the entry's location: a file path or URL.
+	URI string
+}
+
+// Title returns the title from the entry's #EXTINF directive,
+// or "" if there is none.
+func (e *Entry) Title() string {
+	for _, d := range e.Directives {
+		info, ok := strings.CutPrefix(d, "#EXTINF:")
+		if !ok {
+			continue
+		}
+
+		// The title follows the first comma that is not inside a quoted attribute value.
+		inQuotes := false
+		for i, r := range info {
+			switch r {
+			case '"':
+				inQuotes = !inQuotes
+			case ',':
+				if !inQuotes {
+					return strings.TrimSpace(info[i+1:])
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// SortKey returns the bibliographic sort key for the entry:
+// the key of its title if it has one,
+// otherwise the key of its file name.
+func (e *Entry) SortKey() string {
+	if title := e.Title(); title != "" {
+		return bib.Key(title)
+	}
+	return bib.FilenameKey(e.URI)
+}
+
+// Read reads a playlist.
+func Read(r io.Reader) (*Playlist, error) {
+	var (
+		p        = new(Playlist)
+		pending  []string
+		inHeader = true
+		sc       = bufio.NewScanner(r)
+	)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			if inHeader && strings.HasPrefix(line, "#EXTINF:") {
+				p.Header, pending = splitHeader(pending)
+				inHeader = false
+			}
+			pending = append(pending, line)
+
+		default:
+			if inHeader {
+				p.Header, pending = splitHeader(pending)
+				inHeader = false
+			}
+			p.Entries = append(p.Entries, &Entry{Directives: pending, URI: line})
+			pending = nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("reading playlist: %w", err)
+	}
+
+	if inHeader {
+		p.Header = pending
+	} else {
+		p.Trailer = pending
+	}
+	return p, nil
+}
+
+// splitHeader divides the lines preceding a playlist's first entry
+// between the header and that entry.
+// The header extends through the last line that is #EXTM3U or a playlist-wide directive;
+// everything after that belongs to the entry.
+func splitHeader(lines []string) (header, rest []string) {
+	n := 0
+	for i, line := range lines {
+		line = strings.TrimPrefix(line, "\ufeff")
+		if line == "#EXTM3U" || strings.HasPrefix(line, "#PLAYLIST:") || strings.HasPrefix(line, "#EXTENC:") || strings.HasPrefix(line, "#EXT-X-") {
+			n = i + 1
+		}
+	}
+	return lines[:n:n], lines[n:]
+}
+
+// Sort sorts the playlist's entries bibliographically.
+func (p *Playlist) Sort() {
+	keys := slices.Map(p.Entries, (*Entry).SortKey)
+	slices.KeyedSort(p.Entries, sort.StringSlice(keys))
+}
+
+// Write writes the playlist to w.
+func (p *Playlist) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	writeLines := func(lines []string) {
+		for _, line := range lines {
+			bw.WriteString(line)
+			bw.WriteByte('\n')
+		}
+	}
+
+	writeLines(p.Header)
+	for _, e := range p.Entries {
+		writeLines(e.Directives)
+		writeLines([]string{e.URI})
+	}
+	writeLines(p.Trailer)
+
+	return bw.Flush()
+}
@@ -0,0 +1,73 @@
+package m3u
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	const inp = `#EXTM3U
+#PLAYLIST:Road Trip
+#EXTGRP:Soundtracks
+#EXTINF:245,The Gumball Rally Theme
+music/gumball.mp3
+#EXTINF:180 tvg-name="x,y",42nd Street
+music/42nd.mp3
+
+/music/An_Apple_a_Day.flac
+#EXTINF:-1,
+http://example.com/stream/Zoo%20Station.mp3
+#EXT-X-ENDLIST
+`
+	const want = `#EXTM3U
+#PLAYLIST:Road Trip
+
+/music/An_Apple_a_Day.flac
+#EXTINF:180 tvg-name="x,y",42nd Street
+music/42nd.mp3
+#EXTGRP:Soundtracks
+#EXTINF:245,The Gumball Rally Theme
+music/gumball.mp3
+#EXTINF:-1,
+http://example.com/stream/Zoo%20Station.mp3
+#EXT-X-ENDLIST
+`
+
+	p, err := Read(strings.NewReader(inp))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Entries) != 4 {
+		t.Fatalf("got %d entries, want 4", len(p.Entries))
+	}
+	if got := p.Entries[1].Title(); got != "42nd Street" {
+		t.Errorf(`got title "%s", want "42nd Street"`, got)
+	}
+
+	p.Sort()
+
+	buf := new(bytes.Buffer)
+	if err := p.Write(buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPlain(t *testing.T) {
+	const inp = "# my list\nThe Zoo.mp3\nAardvark.mp3\n"
+
+	p, err := Read(strings.NewReader(inp))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Header) != 0 {
+		t.Errorf("got header %v, want none", p.Header)
+	}
+	p.Sort()
+	if got := p.Entries[0].URI; got != "Aardvark.mp3" {
+		t.Errorf(`got first entry "%s", want "Aardvark.mp3"`, got)
+	}
+}
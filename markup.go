@@ -0,0 +1,91 @@
+package bib
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/bobg/go-generics/v4/slices"
+)
+
+// WithMarkupStripping makes a [Keyer] remove HTML and Markdown markup
+// from its input before normalizing it,
+// as found in titles from content-management systems:
+// "<em>Moby-Dick</em>" files as "moby dick,"
+// and "The *Real* Story" as "real story."
+//
+// HTML tags are removed,
+// with block-level tags like <br> and <p> and all closing tags taken as word breaks,
+// but not opening inline tags like <em>,
+// so "Über<i>mensch</i>" stays one word
+// and "<b>Moby</b>Dick" is two.
+// Script and style elements are removed with their contents.
+// Character references like "&amp;" are decoded.
+//
+// Markdown links and images file under their text,
+// and emphasis (*, **, _, __, ~~, and `) is removed
+// only where it opens at the start of a word and closes at the end of one,
+// so "M*A*S*H" and "snake_case" are left alone.
+func WithMarkupStripping() Option {
+	return func(c *config) {
+		c.stripMarkup = true
+	}
+}
+
+// stripMarkup removes HTML and Markdown markup from s.
+func stripMarkup(s string) string {
+	s = htmlCommentRegex.ReplaceAllString(s, "")
+	s = htmlScriptRegex.ReplaceAllString(s, " ")
+	s = htmlTagRegex.ReplaceAllStringFunc(s, func(tag string) string {
+		m := htmlTagRegex.FindStringSubmatch(tag)
+		if strings.HasPrefix(tag, "</") || htmlBlockTags[strings.ToLower(m[1])] {
+			return " "
+		}
+		return ""
+	})
+	s = html.UnescapeString(s)
+
+	s = mdLinkRegex.ReplaceAllString(s, "$1")
+
+	for _, re := range mdEmphasisRegexes {
+		// Each match consumes the characters on either side of it,
+		// which may be needed as the boundary of an adjacent match
+		// ("*a* *b*"),
+		// so repeat until nothing changes.
+		for {
+			t := re.ReplaceAllString(s, "${1}${2}${3}")
+			if t == s {
+				break
+			}
+			s = t
+		}
+	}
+
+	return s
+}
+
+var (
+	htmlCommentRegex = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlScriptRegex  = regexp.MustCompile(`(?is)<script(?:\s[^<>]*)?>.*?</script\s*>|<style(?:\s[^<>]*)?>.*?</style\s*>`)
+	htmlTagRegex     = regexp.MustCompile(`</?([a-zA-Z][a-zA-Z0-9]*)(?:\s[^<>]*)?/?>`)
+	mdLinkRegex      = regexp.MustCompile(`!?\[([^\[\]]*)\]\([^()\s]*(?:\s+"[^"]*")?\)`)
+
+	mdEmphasisRegexes = slices.Map(
+		[]string{`\*\*\*`, `\*\*`, `\*`, `___`, `__`, `_`, `~~`, "`"},
+		func(m string) *regexp.Regexp {
+			return regexp.MustCompile(`(^|[\s(\["'])` + m + `(\S|\S.*?\S)` + m + `($|[\s)\]"'.,;:!?])`)
+		},
+	)
+
+	htmlBlockTags = map[string]bool{
+		"address": true, "article": true, "aside": true, "blockquote": true, "br": true,
+		"caption": true, "dd": true, "details": true, "div": true, "dl": true,
+		"dt": true, "fieldset": true, "figcaption": true, "figure": true, "footer": true,
+		"form": true, "h1": true, "h2": true, "h3": true, "h4": true,
+		"h5": true, "h6": true, "header": true, "hr": true, "img": true,
+		"li": true, "main": true, "nav": true, "ol": true, "p": true,
+		"pre": true, "section": true, "summary": true, "table": true, "tbody": true,
+		"td": true, "tfoot": true, "th": true, "thead": true, "tr": true,
+		"ul": true,
+	}
+)
@@ -0,0 +1,74 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMarkupStripping(t *testing.T) {
+	cases := []struct {
+		inp  string
+		want string
+	}{{
+		inp:  "<em>Moby-Dick</em>",
+		want: "moby dick",
+	}, {
+		inp:  "Über<i>mensch</i>",
+		want: "übermensch",
+	}, {
+		inp:  "Pride<br>Prejudice",
+		want: "pride prejudice",
+	}, {
+		inp:  `<p class="title">The <b>Gumball</b> Rally</p>`,
+		want: "gumball rally",
+	}, {
+		inp:  "Tom &amp; Jerry<!-- draft -->",
+		want: "tom and jerry",
+	}, {
+		inp:  "<script>alert(1)</script>Title",
+		want: "title",
+	}, {
+		inp:  "<STYLE type=\"text/css\">p { color: red }</STYLE>The Title",
+		want: "title",
+	}, {
+		inp:  "<b>Moby</b>Dick",
+		want: "moby dick",
+	}, {
+		inp:  "<td>Pride</td><td>Prejudice</td>",
+		want: "pride prejudice",
+	}, {
+		inp:  "Pride<nav>Prejudice",
+		want: "pride prejudice",
+	}, {
+		inp:  "The *Real* Story",
+		want: "real story",
+	}, {
+		inp:  "**Bold** and _italic_ and ~~struck~~",
+		want: "bold and italic and struck",
+	}, {
+		inp:  "*one* *two*",
+		want: "one two",
+	}, {
+		inp:  "M*A*S*H",
+		want: "mash",
+	}, {
+		inp:  "snake_case_title",
+		want: "snakecasetitle",
+	}, {
+		inp:  "[The Hobbit](https://example.com/hobbit)",
+		want: "hobbit",
+	}, {
+		inp:  "A `for` loop",
+		want: "for loop",
+	}}
+
+	k := NewKeyer(WithMarkupStripping())
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := k.Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`input "%s", got "%s", want "%s"`, tc.inp, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,496 @@
+package bib
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// numberChunk converts a number at the start of a key
+// whose form is more than digits,
+// such as the decimal "3.14,"
+// according to k's [NumberMode].
+// The chunk is as written in the input,
+// with the punctuation around it removed (see trimChunk).
+// It may also be a whole number and a fraction from two chunks,
+// joined by a space,
+// as in "8 1/2."
+// It reports false if chunk is not such a number.
+//
+// If nums is not nil,
+// numberChunk appends the values of the numbers it converts to it
+// (see [NumberSpans]),
+// without their offsets.
+func (k *Keyer) numberChunk(chunk string, nums *[]NumberSpan) ([]string, bool) {
+	if whole, frac, ok := k.decimalParts(chunk); ok {
+		return k.decimal(whole, frac, nums)
+	}
+	if m := rangeRegex.FindStringSubmatch(chunk); m != nil {
+		return k.numberRange(m[1], m[2], nums)
+	}
+	if m := negativeRegex.FindStringSubmatch(chunk); m != nil {
+		return k.negativeNumber(m[1], nums)
+	}
+	if m := slashFractionRegex.FindStringSubmatch(chunk); m != nil {
+		return k.fraction(m[1], m[2], m[3], nums)
+	}
+	if m := vulgarFractionRegex.FindStringSubmatch(chunk); m != nil {
+		f := vulgarFractions[[]rune(m[2])[0]]
+		return k.fraction(m[1], strconv.Itoa(f[0]), strconv.Itoa(f[1]), nums)
+	}
+	if m := currencyRegex.FindStringSubmatch(chunk); m != nil {
+		return k.currency(m[1], m[2], nums)
+	}
+	if m := clockRegex.FindStringSubmatch(chunk); m != nil && !k.cfg.noClockTimes {
+		return k.clockTime(m[1], m[2], m[3], nums)
+	}
+	return nil, false
+}
+
+// addNumbers appends ns to *nums,
+// if nums is not nil.
+func addNumbers(nums *[]NumberSpan, ns ...NumberSpan) {
+	if nums != nil {
+		*nums = append(*nums, ns...)
+	}
+}
+
+// WithDecimalComma makes a [Keyer] read a leading number in the style of much of Europe,
+// with a comma before the fractional part
+// and periods between groups of digits,
+// so that "3,14" is three point one four
+// and "1.000" is one thousand.
+// Without it,
+// "3.14" is three point one four,
+// and "1,000" is one thousand.
+// Either way,
+// a number with more than one separator of the same kind,
+// like "1,000,000" or "1.000.000,"
+// has its digits grouped by them.
+func WithDecimalComma() Option {
+	return func(c *config) {
+		c.decimalComma = true
+	}
+}
+
+var (
+	// decimalRegex matches a decimal number,
+	// with optional thousands separators.
+	decimalRegex = regexp.MustCompile(`^(\d{1,3}(?:,\d{3})+|\d+)\.(\d+)$`)
+
+	// decimalCommaRegex is decimalRegex for WithDecimalComma.
+	decimalCommaRegex = regexp.MustCompile(`^(\d{1,3}(?:\.\d{3})+|\d+),(\d+)$`)
+)
+
+// decimalParts tells whether chunk is a decimal number,
+// punctuated according to [WithDecimalComma],
+// and if so returns its integer digits,
+// without thousands separators,
+// and its fractional digits.
+func (k *Keyer) decimalParts(chunk string) (whole, frac string, ok bool) {
+	if k.cfg.decimalComma {
+		if m := decimalCommaRegex.FindStringSubmatch(chunk); m != nil {
+			return strings.ReplaceAll(m[1], ".", ""), m[2], true
+		}
+	} else if m := decimalRegex.FindStringSubmatch(chunk); m != nil {
+		return strings.ReplaceAll(m[1], ",", ""), m[2], true
+	}
+	return "", "", false
+}
+
+// decimal converts the decimal number with the given integer and fractional digits.
+// Spelled out, "3.14" is "three point one four."
+// By value, it is the encoded integer part,
+// a period,
+// and the fractional digits,
+// which sort in numeric order.
+func (k *Keyer) decimal(whole, frac string, nums *[]NumberSpan) ([]string, bool) {
+	n, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil && !k.byValue() {
+		return nil, false
+	}
+	if nums != nil {
+		f, _ := strconv.ParseFloat("0."+frac, 64)
+		addNumbers(nums, NumberSpan{Value: n, Frac: f})
+	}
+	if k.byValue() {
+		return []string{k.encodeInt(whole) + "." + frac}, true
+	}
+	words := k.spellInt(n, false, false)
+	words = append(words, "point")
+	for _, d := range frac {
+		words = append(words, intToWords(int64(d-'0'), false)...)
+	}
+	return words, true
+}
+
+// rangeRegex matches a range of numbers joined by a hyphen or dash,
+// like "1914–1918."
+var rangeRegex = regexp.MustCompile(`^(\d+)\p{Pd}(\d+)$`)
+
+// numberRange converts a range of numbers,
+// so "1914–1918" is "nineteen fourteen to nineteen eighteen"
+// (or, by value, the two encoded numbers with "to" between them).
+// A second number of at least two digits
+// with fewer digits than the first
+// takes its missing leading digits from the first,
+// so "1914–18" is the same range.
+// It reports false if the second number is not greater than the first,
+// as in "24-7,"
+// which is then not a range.
+func (k *Keyer) numberRange(from, to string, nums *[]NumberSpan) ([]string, bool) {
+	if len(to) >= 2 && len(to) < len(from) {
+		to = from[:len(from)-len(to)] + to
+	}
+	a, err := strconv.ParseInt(from, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	b, err := strconv.ParseInt(to, 10, 64)
+	if err != nil || b <= a {
+		return nil, false
+	}
+	addNumbers(nums, NumberSpan{Value: a}, NumberSpan{Value: b})
+	if k.byValue() {
+		return []string{k.encodeInt(from), "to", k.encodeInt(to)}, true
+	}
+	words := k.intToWords(a, false)
+	words = append(words, "to")
+	return append(words, k.intToWords(b, false)...), true
+}
+
+// WithNegativeWord sets the word for the minus sign of a leading negative number,
+// as in "−40 Degrees."
+// The default is "minus."
+func WithNegativeWord(word string) Option {
+	return func(c *config) {
+		c.negative = word
+	}
+}
+
+// negativeRegex matches a negative integer,
+// with a minus sign or a hyphen or dash standing in for one.
+var negativeRegex = regexp.MustCompile(`^[\p{Pd}\x{2212}](\d+)$`)
+
+// negativeNumber converts the negative of the number with the given digits,
+// so "−40" is "minus forty."
+// By value, it is encoded with encodeNegative.
+func (k *Keyer) negativeNumber(digits string, nums *[]NumberSpan) ([]string, bool) {
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if k.byValue() {
+		addNumbers(nums, NumberSpan{Value: -n})
+		return []string{k.encodeNegative(digits)}, true
+	}
+	if err != nil {
+		return nil, false
+	}
+	addNumbers(nums, NumberSpan{Value: -n})
+	return append([]string{k.negativeWord()}, k.spellInt(n, false, false)...), true
+}
+
+// negativeWord is the word for a minus sign (see [WithNegativeWord]).
+func (k *Keyer) negativeWord() string {
+	if k.cfg.negative == "" {
+		return "minus"
+	}
+	return k.cfg.negative
+}
+
+var (
+	// slashFractionRegex matches a fraction written with a slash
+	// after a whole number,
+	// as in "8 1/2."
+	// Without the whole number,
+	// numbers separated by a slash are too often something else,
+	// like "9/11" or "24/7,"
+	// to be taken as a fraction.
+	slashFractionRegex = regexp.MustCompile(`^(\d+) (\d+)[/\x{2044}](\d+)$`)
+
+	// vulgarFractionRegex matches one of the Unicode vulgar fraction characters,
+	// optionally after a whole number,
+	// as in "½" and "8½."
+	vulgarFractionRegex = regexp.MustCompile(`^(?:(\d+) ?)?([\x{BC}-\x{BE}\x{2150}-\x{215E}])$`)
+)
+
+// vulgarFractions gives the numerator and denominator
+// of each of the Unicode vulgar fraction characters.
+var vulgarFractions = map[rune][2]int{
+	'¼': {1, 4}, '½': {1, 2}, '¾': {3, 4},
+	'⅐': {1, 7}, '⅑': {1, 9}, '⅒': {1, 10},
+	'⅓': {1, 3}, '⅔': {2, 3},
+	'⅕': {1, 5}, '⅖': {2, 5}, '⅗': {3, 5}, '⅘': {4, 5},
+	'⅙': {1, 6}, '⅚': {5, 6},
+	'⅛': {1, 8}, '⅜': {3, 8}, '⅝': {5, 8}, '⅞': {7, 8},
+}
+
+// maxFractionDigits is the number of decimal places
+// to which a fraction is encoded by value.
+const maxFractionDigits = 6
+
+// fraction converts a fraction with the given numerator and denominator,
+// after the given whole number, if any.
+// Spelled out, "8½" is "eight and a half,"
+// "¾" is "three quarters,"
+// and "2 1/8" is "two and an eighth."
+// By value, it is encoded like the decimal number it equals,
+// to six places,
+// so "8½" sorts with "8.5."
+// It reports false for anything but a proper fraction,
+// such as the "3/2" of "1 3/2."
+func (k *Keyer) fraction(whole, num, den string, nums *[]NumberSpan) ([]string, bool) {
+	a, err := strconv.ParseInt(num, 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	b, err := strconv.ParseInt(den, 10, 64)
+	if err != nil || a <= 0 || b <= a {
+		return nil, false
+	}
+	if nums != nil {
+		n, _ := strconv.ParseInt(whole, 10, 64)
+		addNumbers(nums, NumberSpan{Value: n, Frac: float64(a) / float64(b)})
+	}
+
+	if k.byValue() {
+		if whole == "" {
+			whole = "0"
+		}
+		var digits []byte
+		for r := a; r != 0 && len(digits) < maxFractionDigits; r %= b {
+			r *= 10
+			digits = append(digits, byte('0'+r/b))
+		}
+		return []string{k.encodeInt(whole) + "." + string(digits)}, true
+	}
+
+	var words []string
+	if whole != "" {
+		n, err := strconv.ParseInt(whole, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		words = append(k.intToWords(n, false), "and")
+	}
+	var denom []string
+	switch b {
+	case 2:
+		denom = []string{"half"}
+	case 4:
+		denom = []string{"quarter"}
+	default:
+		denom = k.spellInt(b, true, false)
+	}
+	switch {
+	case a == 1 && whole != "" && strings.IndexByte("aeiou", denom[0][0]) >= 0:
+		words = append(words, "an") // "an eighth"
+	case a == 1 && whole != "":
+		words = append(words, "a")
+	default:
+		words = append(words, k.spellInt(a, false, false)...)
+	}
+	if a > 1 {
+		last := denom[len(denom)-1]
+		if last == "half" {
+			last = "halves"
+		} else {
+			last += "s"
+		}
+		denom = append(denom[:len(denom)-1:len(denom)-1], last)
+	}
+	return append(words, denom...), true
+}
+
+// Currency gives the words for an amount of a currency,
+// for [WithCurrencies].
+type Currency struct {
+	// One is the word for one unit, like "dollar."
+	One string
+
+	// Many is the word for other amounts, like "dollars."
+	Many string
+}
+
+// defaultCurrencies are the currency symbols that a [Keyer] spells out
+// before a leading amount.
+var defaultCurrencies = map[string]Currency{
+	"$": {One: "dollar", Many: "dollars"},
+	"£": {One: "pound", Many: "pounds"},
+	"€": {One: "euro", Many: "euros"},
+	"¥": {One: "yen", Many: "yen"},
+	"₹": {One: "rupee", Many: "rupees"},
+}
+
+// WithCurrencies supplies the words for currency symbols
+// before a leading amount,
+// in addition to those for $, £, €, ¥, and ₹,
+// so that e.g. "$5 a Day" files as "five dollars a day."
+// An entry with no words
+// makes a [Keyer] ignore that symbol,
+// as for symbols not in any table.
+//
+// The option may be given more than once;
+// the tables are combined,
+// with later entries replacing earlier ones for the same symbol.
+func WithCurrencies(currencies map[string]Currency) Option {
+	return func(c *config) {
+		if c.currencies == nil {
+			c.currencies = make(map[string]Currency)
+		}
+		maps.Copy(c.currencies, currencies)
+	}
+}
+
+var (
+	// currencyRegex matches an amount after a currency symbol,
+	// as in "$5."
+	currencyRegex = regexp.MustCompile(`^(\p{Sc})(\d.*)$`)
+
+	// groupedIntRegex matches an integer,
+	// with optional thousands separators.
+	groupedIntRegex = regexp.MustCompile(`^(?:\d{1,3}(?:,\d{3})+|\d+)$`)
+
+	// groupedIntCommaRegex is groupedIntRegex for WithDecimalComma.
+	groupedIntCommaRegex = regexp.MustCompile(`^(?:\d{1,3}(?:\.\d{3})+|\d+)$`)
+)
+
+// currency converts an amount after a currency symbol,
+// so "$5" is "five dollars" and "£1" is "one pound."
+// The amount may be an integer,
+// or any of the other forms of numberChunk,
+// as in "$4.99."
+// By value, the currency's word follows the encoded amount.
+// It reports false if the symbol has no words.
+func (k *Keyer) currency(sym, amount string, nums *[]NumberSpan) ([]string, bool) {
+	c, ok := k.cfg.currencies[sym]
+	if !ok {
+		c = defaultCurrencies[sym]
+	}
+	if c.Many == "" {
+		return nil, false
+	}
+
+	var amounts []NumberSpan
+
+	var (
+		words []string
+		one   bool
+	)
+	intRegex, sep := groupedIntRegex, ","
+	if k.cfg.decimalComma {
+		intRegex, sep = groupedIntCommaRegex, "."
+	}
+	if intRegex.MatchString(amount) {
+		digits := strings.ReplaceAll(amount, sep, "")
+		n, err := strconv.ParseInt(digits, 10, 64)
+		if k.byValue() {
+			words = []string{k.encodeInt(digits)}
+		} else if err != nil {
+			return nil, false
+		} else {
+			words = k.spellInt(n, false, false)
+		}
+		amounts = []NumberSpan{{Value: n}}
+		one = strings.TrimLeft(digits, "0") == "1"
+	} else if words, ok = k.numberChunk(amount, &amounts); !ok {
+		return nil, false
+	}
+	for i := range amounts {
+		amounts[i].Currency = sym
+	}
+	addNumbers(nums, amounts...)
+
+	if one {
+		return append(words, c.One), true
+	}
+	return append(words, c.Many), true
+}
+
+// encodeNegative encodes the negative of the number with the given digits
+// to sort before zero and all positive numbers (see encodeInt),
+// and before negative numbers of smaller magnitude:
+// it is a hyphen,
+// 99 minus the number of significant digits, as two digits,
+// and the nines' complement of each significant digit.
+func (k *Keyer) encodeNegative(digits string) string {
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return k.encodeInt("0")
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "-%02d", 99-len(digits))
+	for _, d := range digits {
+		b.WriteRune('9' - d + '0')
+	}
+	return b.String()
+}
+
+// WithoutClockTimes makes a [Keyer] treat a leading time of day,
+// as in "3:10 to Yuma,"
+// like any other number,
+// whose digits run together:
+// "three hundred ten to yuma."
+// Without this option,
+// it is "three ten to yuma."
+func WithoutClockTimes() Option {
+	return func(c *config) {
+		c.noClockTimes = true
+	}
+}
+
+// clockRegex matches a time of day,
+// with an optional "am" or "pm."
+var clockRegex = regexp.MustCompile(`^([01]?\d|2[0-4]):([0-5]\d)(?i:([ap])\.?m\.?)?$`)
+
+// clockTime converts a time of day,
+// so "3:10" is "three ten,"
+// "9:05" is "nine oh five,"
+// "12:00" is "twelve oclock"
+// (as "o'clock" is keyed),
+// and "6:30pm" is "six thirty pm."
+// By value, it is the encoded hour, a colon, and the minutes,
+// followed by any "am" or "pm."
+func (k *Keyer) clockTime(hour, minute, ampm string, nums *[]NumberSpan) ([]string, bool) {
+	h, _ := strconv.ParseInt(hour, 10, 64)
+	m, _ := strconv.ParseInt(minute, 10, 64)
+	if nums != nil {
+		h24 := h
+		switch strings.ToLower(ampm) {
+		case "a":
+			h24 = h % 12
+		case "p":
+			h24 = h%12 + 12
+		}
+		addNumbers(nums, NumberSpan{Value: h24, Minute: int(m), Clock: true})
+	}
+
+	var words []string
+	if k.byValue() {
+		words = []string{k.encodeInt(hour) + ":" + minute}
+	} else {
+		words = k.spellInt(h, false, false)
+		switch {
+		case m == 0:
+			words = append(words, "oclock")
+		case m < 10:
+			words = append(words, "oh")
+			words = append(words, k.spellInt(m, false, false)...)
+		default:
+			words = append(words, k.spellInt(m, false, false)...)
+		}
+	}
+	if ampm != "" {
+		words = append(words, strings.ToLower(ampm)+"m")
+	}
+	return words, true
+}
+
+// trimChunk removes the punctuation that may surround a number in a title,
+// as in "(3.14)" or "3.14:" or "“3.14.”"
+func trimChunk(chunk string) string {
+	return strings.TrimFunc(chunk, func(r rune) bool {
+		return unicode.In(r, unicode.Ps, unicode.Pe, unicode.Pi, unicode.Pf) || strings.ContainsRune(`.,;:!?'"`, r)
+	})
+}
@@ -0,0 +1,347 @@
+package bib
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDecimal(t *testing.T) {
+	cases := []struct {
+		mode      NumberMode
+		inp, want string
+	}{{
+		inp:  "3.14 and Other Tales",
+		want: "three point one four and other tales",
+	}, {
+		inp:  "(1,234.5)",
+		want: "one thousand two hundred thirty-four point five",
+	}, {
+		inp:  "1984.5: A Novel",
+		want: "one thousand nine hundred eighty-four point five a novel",
+	}, {
+		inp:  "Windows 3.1",
+		want: "windows 31",
+	}, {
+		inp:  "1.000.000",
+		want: "one million",
+	}, {
+		mode: NumbersByValue,
+		inp:  "3.14 and Other Tales",
+		want: "013.14 and other tales",
+	}, {
+		mode: NumbersAsWritten,
+		inp:  "3.14 and Other Tales",
+		want: "314 and other tales",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(WithNumberMode(tc.mode)).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDecimalOrder(t *testing.T) {
+	x := []string{"3.2 Reasons", "3 Musketeers", "10 Things", "3.14 Reasons"}
+	SortWith(x, WithNumberMode(NumbersByValue))
+	want := []string{"3 Musketeers", "3.14 Reasons", "3.2 Reasons", "10 Things"}
+	if !reflect.DeepEqual(x, want) {
+		t.Errorf("got %q, want %q", x, want)
+	}
+}
+
+func TestThousands(t *testing.T) {
+	cases := []struct {
+		opts      []Option
+		inp, want string
+	}{{
+		inp:  "1,000,000 Ways to Die",
+		want: "one million ways to die",
+	}, {
+		inp:  "1.000.000 Ways to Die",
+		want: "one million ways to die",
+	}, {
+		inp:  "1,000th Night",
+		want: "one thousandth night",
+	}, {
+		inp:  "1.000 Nights",
+		want: "one point zero zero zero nights",
+	}, {
+		opts: []Option{WithDecimalComma()},
+		inp:  "1.000 Nights",
+		want: "one thousand nights",
+	}, {
+		opts: []Option{WithDecimalComma()},
+		inp:  "3,14 Gründe",
+		want: "three point one four gründe",
+	}, {
+		opts: []Option{WithDecimalComma()},
+		inp:  "1.234,5",
+		want: "one thousand two hundred thirty-four point five",
+	}, {
+		opts: []Option{WithDecimalComma()},
+		inp:  "1,000,000",
+		want: "one million",
+	}, {
+		opts: []Option{WithDecimalComma(), WithNumberMode(NumbersByValue)},
+		inp:  "3,14 Gründe",
+		want: "013.14 gründe",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(tc.opts...).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRange(t *testing.T) {
+	cases := []struct {
+		mode      NumberMode
+		inp, want string
+	}{{
+		inp:  "1914–1918: The Great War",
+		want: "nineteen fourteen to nineteen eighteen the great war",
+	}, {
+		inp:  "1914-18",
+		want: "nineteen fourteen to nineteen eighteen",
+	}, {
+		inp:  "9—12",
+		want: "nine to twelve",
+	}, {
+		inp:  "24-7",
+		want: "twenty-four 7",
+	}, {
+		inp:  "1-2-3",
+		want: "one 2 3",
+	}, {
+		mode: NumbersByValue,
+		inp:  "1914–1918: The Great War",
+		want: "041914 to 041918 the great war",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(WithNumberMode(tc.mode)).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNegative(t *testing.T) {
+	cases := []struct {
+		opts      []Option
+		inp, want string
+	}{{
+		inp:  "−40 Degrees",
+		want: "minus forty degrees",
+	}, {
+		inp:  "-1984",
+		want: "minus one thousand nine hundred eighty-four",
+	}, {
+		opts: []Option{WithNegativeWord("negative")},
+		inp:  "-40 Degrees",
+		want: "negative forty degrees",
+	}, {
+		opts: []Option{WithNumberMode(NumbersAsWritten)},
+		inp:  "−40 Degrees",
+		want: "40 degrees",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(tc.opts...).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNegativeOrder(t *testing.T) {
+	x := []string{"5 Degrees", "-5 Degrees", "0 Degrees", "−40 Degrees", "-45 Degrees", "Absolute Zero", "-500 Degrees"}
+	SortWith(x, WithNumberMode(NumbersByValue))
+	want := []string{"-500 Degrees", "-45 Degrees", "−40 Degrees", "-5 Degrees", "0 Degrees", "5 Degrees", "Absolute Zero"}
+	if !reflect.DeepEqual(x, want) {
+		t.Errorf("got %q, want %q", x, want)
+	}
+}
+
+func TestFraction(t *testing.T) {
+	cases := []struct {
+		opts      []Option
+		inp, want string
+	}{{
+		inp:  "8½",
+		want: "eight and a half",
+	}, {
+		inp:  "8 1/2 Weeks",
+		want: "eight and a half weeks",
+	}, {
+		inp:  "8 ½",
+		want: "eight and a half",
+	}, {
+		inp:  "½ Moon",
+		want: "one half moon",
+	}, {
+		inp:  "¾ Time",
+		want: "three quarters time",
+	}, {
+		inp:  "2⅔ Cups",
+		want: "two and two thirds cups",
+	}, {
+		inp:  "1/8 Inch",
+		want: "eighteen inch",
+	}, {
+		inp:  "9/11",
+		want: "nine hundred eleven",
+	}, {
+		inp:  "2 1/8 Inches",
+		want: "two and an eighth inches",
+	}, {
+		inp:  "3 3/2 Weeks",
+		want: "three 32 weeks",
+	}, {
+		inp:  "24/7",
+		want: "two hundred forty-seven",
+	}, {
+		opts: []Option{WithNumberMode(NumbersByValue)},
+		inp:  "8½",
+		want: "018.5",
+	}, {
+		opts: []Option{WithNumberMode(NumbersByValue)},
+		inp:  "⅓ Off",
+		want: "00.333333 off",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(tc.opts...).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCurrencyWith(t *testing.T) {
+	k := NewKeyer(WithCurrencies(map[string]Currency{"₩": {One: "won", Many: "won"}}))
+	k2 := k.With(WithCurrencies(map[string]Currency{"₩": {}}))
+	if got, want := k.Key("₩500"), "five hundred won"; got != want {
+		t.Errorf(`got "%s", want "%s"`, got, want)
+	}
+	if got, want := k2.Key("₩500"), "five hundred"; got != want {
+		t.Errorf(`with override, got "%s", want "%s"`, got, want)
+	}
+}
+
+func TestCurrency(t *testing.T) {
+	cases := []struct {
+		opts      []Option
+		inp, want string
+	}{{
+		inp:  "$5 a Day",
+		want: "five dollars a day",
+	}, {
+		inp:  "£10 Poms",
+		want: "ten pounds poms",
+	}, {
+		inp:  "$1 Hamburgers",
+		want: "one dollar hamburgers",
+	}, {
+		inp:  "$1,000,000 Duck",
+		want: "one million dollars duck",
+	}, {
+		inp:  "$1984",
+		want: "one thousand nine hundred eighty-four dollars",
+	}, {
+		inp:  "$4.99 Specials",
+		want: "four point nine nine dollars specials",
+	}, {
+		inp:  "¥100",
+		want: "one hundred yen",
+	}, {
+		inp:  "₩500",
+		want: "five hundred",
+	}, {
+		opts: []Option{WithCurrencies(map[string]Currency{"₩": {One: "won", Many: "won"}})},
+		inp:  "₩500",
+		want: "five hundred won",
+	}, {
+		opts: []Option{WithCurrencies(map[string]Currency{"$": {}})},
+		inp:  "$5 a Day",
+		want: "five a day",
+	}, {
+		opts: []Option{WithDecimalComma()},
+		inp:  "€1.000",
+		want: "one thousand euros",
+	}, {
+		opts: []Option{WithNumberMode(NumbersByValue)},
+		inp:  "$5 a Day",
+		want: "015 dollars a day",
+	}, {
+		opts: []Option{WithNumberMode(NumbersAsWritten)},
+		inp:  "$5 a Day",
+		want: "5 a day",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(tc.opts...).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClockTime(t *testing.T) {
+	cases := []struct {
+		opts      []Option
+		inp, want string
+	}{{
+		inp:  "3:10 to Yuma",
+		want: "three ten to yuma",
+	}, {
+		inp:  "9:05 Express",
+		want: "nine oh five express",
+	}, {
+		inp:  "12:00",
+		want: "twelve oclock",
+	}, {
+		inp:  "6:30pm",
+		want: "six thirty pm",
+	}, {
+		inp:  "11:59 P.M.",
+		want: "eleven fifty-nine pm",
+	}, {
+		inp:  "3:75",
+		want: "three hundred seventy-five",
+	}, {
+		opts: []Option{WithoutClockTimes()},
+		inp:  "3:10 to Yuma",
+		want: "three hundred ten to yuma",
+	}, {
+		opts: []Option{WithNumberMode(NumbersByValue)},
+		inp:  "3:10 to Yuma",
+		want: "013:10 to yuma",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(tc.opts...).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
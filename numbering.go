@@ -0,0 +1,167 @@
+package bib
+
+// NumberingSystem says how a [Keyer] spells out large numbers.
+type NumberingSystem int
+
+const (
+	// NumberingWestern groups digits by thousands:
+	// 5000000 is "five million."
+	// This is the default.
+	NumberingWestern NumberingSystem = iota
+
+	// NumberingIndian uses the Indian English groupings lakh (100,000) and crore (10,000,000):
+	// 500000 is "five lakh" and 50000000 is "five crore,"
+	// so that they file with titles like "5 Lakh Stories."
+	// Numbers below one lakh are spelled as in NumberingWestern.
+	NumberingIndian
+)
+
+// WithNumbering sets the numbering system used for spelling out numbers.
+// The default is [NumberingWestern].
+//
+// Digit-grouping commas are ignored in any case,
+// so "1,00,000" and "100,000" are both the number 100000.
+func WithNumbering(ns NumberingSystem) Option {
+	return func(c *config) {
+		c.numbering = ns
+	}
+}
+
+// WithoutYears makes a [Keyer] spell out numbers from 1100 through 2999
+// as ordinary numbers,
+// so that "1900" files as "one thousand nine hundred"
+// rather than as the year "nineteen hundred,"
+// and "1984" as "one thousand nine hundred eighty-four"
+// rather than "nineteen eighty-four,"
+// and "1960s" as "one thousand nine hundred sixties."
+// Ordinals like "20th Century Fox" ("twentieth century fox")
+// are unaffected,
+// as are years marked with an era,
+// so "AD 1066" still files as "ten sixty-six ad."
+func WithoutYears() Option {
+	return func(c *config) {
+		c.noYears = true
+	}
+}
+
+// YearRange is a range of numbers,
+// from From through To,
+// that a [Keyer] spells out as years.
+// See [WithYearRanges].
+type YearRange struct {
+	From, To int64
+}
+
+// defaultYearRanges are the ranges of numbers spelled as years by default:
+// 1100 through 2999,
+// except 2000 through 2009,
+// which are "two thousand," "two thousand one," and so on.
+var defaultYearRanges = []YearRange{{From: 1100, To: 1999}, {From: 2010, To: 2999}}
+
+// WithYearRanges sets the ranges of numbers that a [Keyer] spells out as years,
+// as in "nineteen seventeen,"
+// in place of 1100 through 1999 and 2010 through 2999.
+// For example,
+// with only the range 1100 through 1999,
+// "2010" files as "two thousand ten"
+// rather than "twenty ten."
+// Only numbers from 1000 through 9999 can be spelled as years;
+// other numbers in the ranges are spelled as usual.
+// With no ranges,
+// no number is spelled as a year,
+// as with [WithoutYears].
+// Years marked with an era, as in "1066 AD,"
+// get the year reading whatever the ranges.
+func WithYearRanges(ranges ...YearRange) Option {
+	return func(c *config) {
+		c.yearRanges = append([]YearRange{}, ranges...)
+	}
+}
+
+// WithOhYears makes a [Keyer] spell out years ending in 01 through 09
+// as they are usually said,
+// so "1907" files as "nineteen oh seven"
+// rather than "nineteen hundred seven."
+// Years ending in 00 are still "nineteen hundred" and the like.
+// See also [WithYearRanges].
+func WithOhYears() Option {
+	return func(c *config) {
+		c.ohYears = true
+	}
+}
+
+// WithBritishAnd makes a [Keyer] spell out numbers in the British style,
+// with "and" before the last part of a number below one hundred:
+// "101 Dalmatians" files as "one hundred and one dalmatians,"
+// after "One Hundred Anchovies,"
+// and "1901" as "nineteen hundred and one."
+func WithBritishAnd() Option {
+	return func(c *config) {
+		c.britishAnd = true
+	}
+}
+
+// intToWords spells out n according to k's numbering system,
+// as a year if it is in the range for years
+// (unless [WithoutYears] was given).
+func (k *Keyer) intToWords(n int64, ordinal bool) []string {
+	return k.spellInt(n, ordinal, !k.cfg.noYears)
+}
+
+// spellInt spells out n according to k's numbering system.
+// See [spellInt].
+func (k *Keyer) spellInt(n int64, ordinal, years bool) []string {
+	return k.spellWith(n, ordinal, k.spelling(years))
+}
+
+// spellWith spells out n according to k's numbering system and sp.
+func (k *Keyer) spellWith(n int64, ordinal bool, sp spelling) []string {
+	if k.cfg.numbering == NumberingIndian {
+		return spellIntIndian(n, ordinal, sp)
+	}
+	return spellInt(n, ordinal, sp)
+}
+
+// spelling is the spelling of numbers that k's options call for.
+func (k *Keyer) spelling(years bool) spelling {
+	return spelling{
+		years:      years,
+		yearRanges: k.cfg.yearRanges,
+		oh:         k.cfg.ohYears,
+		and:        k.cfg.britishAnd,
+	}
+}
+
+func spellIntIndian(n int64, ordinal bool, sp spelling) []string {
+	const (
+		lakh  = 100000
+		crore = 100 * lakh
+	)
+
+	var (
+		q, r int64
+		unit string
+	)
+	switch {
+	case n < lakh:
+		return spellInt(n, ordinal, sp)
+	case n < crore:
+		q, r, unit = n/lakh, n%lakh, "lakh"
+	default:
+		q, r, unit = n/crore, n%crore, "crore"
+	}
+
+	parts := spelling{and: sp.and} // see spellInt
+	w := spellIntIndian(q, false, parts)
+	w = append(w, unit)
+	if r > 0 {
+		if r < 100 && sp.and {
+			w = append(w, "and")
+		}
+		w = append(w, spellIntIndian(r, ordinal, parts)...)
+	}
+	if ordinal && r == 0 {
+		w[len(w)-1] += "th"
+	}
+	return w
+}
@@ -0,0 +1,286 @@
+package bib
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestNumbering(t *testing.T) {
+	cases := []struct {
+		inp  string
+		ns   NumberingSystem
+		want string
+	}{{
+		inp:  "5 Lakh Stories",
+		ns:   NumberingIndian,
+		want: "five lakh stories",
+	}, {
+		inp:  "500000 Stories",
+		ns:   NumberingIndian,
+		want: "five lakh stories",
+	}, {
+		inp:  "5,00,000 Stories",
+		ns:   NumberingIndian,
+		want: "five lakh stories",
+	}, {
+		inp:  "50000000",
+		ns:   NumberingIndian,
+		want: "five crore",
+	}, {
+		inp:  "50000000",
+		ns:   NumberingWestern,
+		want: "fifty million",
+	}, {
+		inp:  "1,23,45,678",
+		ns:   NumberingIndian,
+		want: "one crore twenty-three lakh forty-five thousand six hundred seventy-eight",
+	}, {
+		inp:  "1000000000000",
+		ns:   NumberingIndian,
+		want: "one lakh crore",
+	}, {
+		inp:  "1984",
+		ns:   NumberingIndian,
+		want: "nineteen eighty-four",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(WithNumbering(tc.ns)).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`input "%s", numbering %d, got "%s", want "%s"`, tc.inp, tc.ns, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestYears(t *testing.T) {
+	cases := []struct {
+		opts []Option
+		inp  string
+		want string
+	}{{
+		inp:  "1900",
+		want: "nineteen hundred",
+	}, {
+		inp:  "1984",
+		want: "nineteen eighty-four",
+	}, {
+		inp:  "20th Century Fox",
+		want: "twentieth century fox",
+	}, {
+		inp:  "1,001,984 Things",
+		want: "one million one thousand nine hundred eighty-four things",
+	}, {
+		opts: []Option{WithNumbering(NumberingIndian)},
+		inp:  "1,00,01,984",
+		want: "one crore one thousand nine hundred eighty-four",
+	}, {
+		opts: []Option{WithoutYears()},
+		inp:  "1900",
+		want: "one thousand nine hundred",
+	}, {
+		opts: []Option{WithoutYears()},
+		inp:  "1984",
+		want: "one thousand nine hundred eighty-four",
+	}, {
+		opts: []Option{WithoutYears()},
+		inp:  "20th Century Fox",
+		want: "twentieth century fox",
+	}, {
+		opts: []Option{WithoutYears()},
+		inp:  "The 1900s",
+		want: "one thousand nine hundreds",
+	}, {
+		opts: []Option{WithoutYears()},
+		inp:  "The 1960s",
+		want: "one thousand nine hundred sixties",
+	}, {
+		opts: []Option{WithBritishAnd()},
+		inp:  "The 1960s",
+		want: "nineteen sixties",
+	}, {
+		opts: []Option{WithoutYears(), WithBritishAnd()},
+		inp:  "The 1960s",
+		want: "one thousand nine hundred and sixties",
+	}, {
+		opts: []Option{WithNumberMode(NumbersByValue)},
+		inp:  "The 1960s",
+		want: "041960s",
+	}, {
+		opts: []Option{WithoutYears(), WithNumbering(NumberingIndian)},
+		inp:  "1984",
+		want: "one thousand nine hundred eighty-four",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(tc.opts...).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+
+	if got := NewKeyer(WithoutYears()).NumberWords(1984); !slices.Equal(got, []string{"one", "thousand", "nine", "hundred", "eighty-four"}) {
+		t.Errorf("NumberWords with WithoutYears: got %q", got)
+	}
+}
+
+func TestBritishAnd(t *testing.T) {
+	cases := []struct {
+		opts []Option
+		inp  string
+		want string
+	}{{
+		inp:  "101 Dalmatians",
+		want: "one hundred one dalmatians",
+	}, {
+		opts: []Option{WithBritishAnd()},
+		inp:  "101 Dalmatians",
+		want: "one hundred and one dalmatians",
+	}, {
+		opts: []Option{WithBritishAnd()},
+		inp:  "120 Days",
+		want: "one hundred and twenty days",
+	}, {
+		opts: []Option{WithBritishAnd()},
+		inp:  "1001 Nights",
+		want: "one thousand and one nights",
+	}, {
+		opts: []Option{WithBritishAnd()},
+		inp:  "2525",
+		want: "twenty-five twenty-five",
+	}, {
+		opts: []Option{WithBritishAnd()},
+		inp:  "1901",
+		want: "nineteen hundred and one",
+	}, {
+		opts: []Option{WithBritishAnd()},
+		inp:  "3,500,050",
+		want: "three million five hundred thousand and fifty",
+	}, {
+		opts: []Option{WithBritishAnd()},
+		inp:  "100th Window",
+		want: "one hundredth window",
+	}, {
+		opts: []Option{WithBritishAnd(), WithNumbering(NumberingIndian)},
+		inp:  "500001",
+		want: "five lakh and one",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(tc.opts...).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+
+	x := []string{"101 Dalmatians", "100 Anchovies"}
+	SortWith(x, WithBritishAnd())
+	if want := []string{"100 Anchovies", "101 Dalmatians"}; !slices.Equal(x, want) {
+		t.Errorf("got %q, want %q", x, want)
+	}
+}
+
+func TestYearRanges(t *testing.T) {
+	cases := []struct {
+		opts []Option
+		inp  string
+		want string
+	}{{
+		inp:  "2010",
+		want: "twenty ten",
+	}, {
+		inp:  "2001: A Space Odyssey",
+		want: "two thousand one a space odyssey",
+	}, {
+		opts: []Option{WithYearRanges(YearRange{From: 1100, To: 1999})},
+		inp:  "2010",
+		want: "two thousand ten",
+	}, {
+		opts: []Option{WithYearRanges(YearRange{From: 1100, To: 1999})},
+		inp:  "1984",
+		want: "nineteen eighty-four",
+	}, {
+		opts: []Option{WithYearRanges(YearRange{From: 1000, To: 2999})},
+		inp:  "2001: A Space Odyssey",
+		want: "twenty hundred one a space odyssey",
+	}, {
+		opts: []Option{WithYearRanges(YearRange{From: 1000, To: 2999})},
+		inp:  "1066 and All That",
+		want: "ten sixty-six and all that",
+	}, {
+		opts: []Option{WithYearRanges()},
+		inp:  "1984",
+		want: "one thousand nine hundred eighty-four",
+	}, {
+		opts: []Option{WithYearRanges(YearRange{From: 100, To: 99999})},
+		inp:  "12345",
+		want: "twelve thousand three hundred forty-five",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(tc.opts...).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOhYears(t *testing.T) {
+	cases := []struct {
+		opts []Option
+		inp  string
+		want string
+	}{{
+		inp:  "1907",
+		want: "nineteen hundred seven",
+	}, {
+		opts: []Option{WithOhYears()},
+		inp:  "1907",
+		want: "nineteen oh seven",
+	}, {
+		opts: []Option{WithOhYears()},
+		inp:  "1901 Census",
+		want: "nineteen oh one census",
+	}, {
+		opts: []Option{WithOhYears()},
+		inp:  "1900",
+		want: "nineteen hundred",
+	}, {
+		opts: []Option{WithOhYears()},
+		inp:  "1984",
+		want: "nineteen eighty-four",
+	}, {
+		opts: []Option{WithOhYears()},
+		inp:  "2001: A Space Odyssey",
+		want: "two thousand one a space odyssey",
+	}, {
+		opts: []Option{WithOhYears(), WithYearRanges(YearRange{From: 1100, To: 2999})},
+		inp:  "2001: A Space Odyssey",
+		want: "twenty oh one a space odyssey",
+	}, {
+		opts: []Option{WithOhYears(), WithBritishAnd()},
+		inp:  "1907",
+		want: "nineteen oh seven",
+	}, {
+		opts: []Option{WithOhYears()},
+		inp:  "1905–1907",
+		want: "nineteen oh five to nineteen oh seven",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(tc.opts...).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
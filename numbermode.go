@@ -0,0 +1,242 @@
+package bib
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bobg/go-generics/v4/slices"
+)
+
+// NumberMode says how a [Keyer] treats a number at the start of its input.
+type NumberMode int
+
+const (
+	// NumbersSpelled files a leading number as if spelled out,
+	// so "42nd Street" files as "forty-second street,"
+	// "The 1920s" as "nineteen twenties,"
+	// "3.14 Reasons" as "three point one four reasons,"
+	// and "1914–1918" as "nineteen fourteen to nineteen eighteen."
+	// This is the default.
+	NumbersSpelled NumberMode = iota
+
+	// NumbersAsWritten files a leading number as written,
+	// so "42nd Street" files as "42nd street."
+	// Since digits precede letters,
+	// such titles sort before all others,
+	// with "10" before "9."
+	NumbersAsWritten
+
+	// NumbersByValue files leading numbers in numeric order,
+	// before all titles that begin with a letter,
+	// so that "9 to 5" precedes "42nd Street,"
+	// which precedes "1917."
+	NumbersByValue
+
+	// NumbersAllByValue is like NumbersByValue,
+	// but files every number in the input in numeric order,
+	// not only a leading one,
+	// so that "Part 9" precedes "Part 10."
+	NumbersAllByValue
+
+	// NumbersLast is like NumbersByValue,
+	// but files titles beginning with a number
+	// after all titles that begin with a letter,
+	// as some library systems do.
+	// They still sort before input with nothing to file on
+	// when that is placed last (see [WithPlacement]).
+	NumbersLast
+)
+
+// numbersLastPrefix begins the key of a title beginning with a number,
+// for [NumbersLast].
+// It sorts after all letters,
+// and before lastKey.
+var numbersLastPrefix = string(utf8.MaxRune - 1)
+
+// WithNumberMode sets how a [Keyer] treats a number at the start of its input.
+// See also [WithNumbering].
+func WithNumberMode(mode NumberMode) Option {
+	return func(c *config) {
+		c.numberMode = mode
+	}
+}
+
+// byValue tells whether k files numbers by value.
+func (k *Keyer) byValue() bool {
+	switch k.cfg.numberMode {
+	case NumbersByValue, NumbersAllByValue, NumbersLast:
+		return true
+	}
+	return false
+}
+
+// decimalWordRegex matches the word that appendChunk makes of a decimal number
+// for [NumbersAllByValue].
+var decimalWordRegex = regexp.MustCompile(`^(\d+)\.(\d+)$`)
+
+// encodeNumbers encodes by value each of words that is a number, decimal number, or decade,
+// for [NumbersAllByValue].
+// A decimal number is encoded like a leading one (see [Keyer.decimal]),
+// so "Part 3.5" files between "Part 3" and "Part 4."
+func (k *Keyer) encodeNumbers(words []string) {
+	for i, w := range words {
+		if m := numRegex.FindStringSubmatch(w); len(m) > 0 {
+			words[i] = k.encodeInt(m[1])
+		} else if m := decimalWordRegex.FindStringSubmatch(w); len(m) > 0 {
+			words[i] = k.encodeInt(m[1]) + "." + m[2]
+		} else if m := decadeRegex.FindStringSubmatch(w); len(m) > 0 {
+			words[i] = k.encodeInt(m[1]) + "s"
+		}
+	}
+}
+
+// WithPaddedNumbers makes a [Keyer] using [NumbersByValue] or [NumbersAllByValue]
+// encode a number as its digits zero-padded to the given width,
+// so "42nd Street" files as "00000000000000000042 street"
+// with the default width of 20
+// (enough for any number that fits in an int64).
+// Such keys order numbers by value under plain byte comparison,
+// e.g. in a database,
+// and are easier to read than the default encoding,
+// which is the number of digits followed by the digits ("0242 street").
+// A number with more digits than the width is not padded,
+// and may sort out of order.
+// A width of zero or less means the default.
+//
+// Since spelled-out numbers have no digits to pad,
+// this option also changes the default mode, [NumbersSpelled],
+// to [NumbersByValue].
+// It has no effect with [NumbersAsWritten].
+func WithPaddedNumbers(width int) Option {
+	return func(c *config) {
+		if width <= 0 {
+			width = defaultPadWidth
+		}
+		c.padWidth = width
+		if c.numberMode == NumbersSpelled {
+			c.numberMode = NumbersByValue
+		}
+	}
+}
+
+const defaultPadWidth = 20
+
+// encodeInt encodes a string of decimal digits
+// according to [WithPaddedNumbers],
+// or else with the package function encodeInt.
+func (k *Keyer) encodeInt(digits string) string {
+	if k.cfg.padWidth > 0 {
+		digits = strings.TrimLeft(digits, "0")
+		if len(digits) < k.cfg.padWidth {
+			return strings.Repeat("0", k.cfg.padWidth-len(digits)) + digits
+		}
+		return digits
+	}
+	return encodeInt(digits)
+}
+
+// leadingNumber converts a number or decade that is the first of words,
+// which come from toks (taken from s),
+// according to k's [NumberMode].
+// It returns the new words
+// and the number of words at the start of words that were replaced,
+// which come from the first chunk of s
+// or, for a number like "8 1/2" or "44 BC," the first two.
+// It reports false if there is nothing to convert.
+//
+// If nums is not nil,
+// leadingNumber appends the numbers it converts to it
+// (see [NumberSpans]).
+func (k *Keyer) leadingNumber(s string, toks []token, words []string, nums *[]NumberSpan) ([]string, int, bool) {
+	if k.cfg.numberMode == NumbersAsWritten {
+		return nil, 0, false
+	}
+
+	var vals []NumberSpan
+	if nums != nil {
+		defer func() { *nums = append(*nums, vals...) }()
+	}
+
+	n := 1
+	for n < len(toks) && toks[n].start == toks[0].start {
+		n++
+	}
+	if g, ok := k.era(toks, &vals); ok {
+		locateDigits(s, toks[0].start, toks[1].end, vals)
+		return slices.ReplaceN(words, 0, 2, g...), 2, true
+	}
+
+	first := trimChunk(s[toks[0].start:toks[0].end])
+	if n < len(toks) && allDigits(first) {
+		// Perhaps a whole number and a fraction, as in "8 1/2."
+		m := n + 1
+		for m < len(toks) && toks[m].start == toks[n].start {
+			m++
+		}
+		if g, ok := k.numberChunk(first+" "+trimChunk(s[toks[n].start:toks[n].end]), &vals); ok {
+			locateNumbers(s, toks[0].start, toks[m-1].end, vals)
+			return slices.ReplaceN(words, 0, m, g...), m, true
+		}
+	}
+	if g, ok := k.numberChunk(first, &vals); ok {
+		locateNumbers(s, toks[0].start, toks[0].end, vals)
+		return slices.ReplaceN(words, 0, n, g...), n, true
+	}
+
+	var g []string
+	if m := numRegex.FindStringSubmatch(words[0]); len(m) > 0 {
+		v, _ := strconv.ParseInt(m[1], 10, 64)
+		vals = []NumberSpan{{Value: v, Ordinal: len(m[2]) > 0}}
+		if k.byValue() {
+			g = []string{k.encodeInt(m[1])}
+		} else {
+			g = k.intToWords(v, len(m[2]) > 0)
+		}
+	} else if m := decadeRegex.FindStringSubmatch(words[0]); len(m) > 0 {
+		v, _ := strconv.ParseInt(m[1], 10, 64)
+		vals = []NumberSpan{{Value: v, Decade: true}}
+		if k.byValue() {
+			g = []string{k.encodeInt(m[1]) + "s"}
+		} else {
+			g = k.decadeToWords(v)
+		}
+	} else {
+		return nil, 0, false
+	}
+	locateDigits(s, toks[0].start, toks[0].end, vals)
+	return slices.ReplaceN(words, 0, 1, g...), 1, true
+}
+
+// locateNumbers sets the offsets of the numbers in vals,
+// which were converted from s[start:end].
+// A single number spans all of that but the punctuation around it (see trimChunk),
+// so that e.g. "−40" and "$4.99" include their signs.
+// The two numbers of a range span their digits.
+func locateNumbers(s string, start, end int, vals []NumberSpan) {
+	if len(vals) != 1 {
+		locateDigits(s, start, end, vals)
+		return
+	}
+	chunk := s[start:end]
+	trimmed := trimChunk(chunk)
+	vals[0].Start = start + strings.Index(chunk, trimmed)
+	vals[0].End = vals[0].Start + len(trimmed)
+	vals[0].Spelled = true
+}
+
+// locateDigits sets the offsets of the numbers in vals
+// to those of the numbers written in s[start:end], in order,
+// as found by writtenNumberRegex.
+func locateDigits(s string, start, end int, vals []NumberSpan) {
+	locs := writtenNumberRegex.FindAllStringIndex(s[start:end], len(vals))
+	for i := range vals {
+		if i < len(locs) {
+			vals[i].Start, vals[i].End = start+locs[i][0], start+locs[i][1]
+		} else {
+			vals[i].Start, vals[i].End = start, end
+		}
+		vals[i].Spelled = true
+	}
+}
@@ -0,0 +1,170 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNumberMode(t *testing.T) {
+	cases := []struct {
+		mode      NumberMode
+		inp, want string
+	}{{
+		mode: NumbersSpelled,
+		inp:  "42nd Street",
+		want: "forty-second street",
+	}, {
+		mode: NumbersAsWritten,
+		inp:  "42nd Street",
+		want: "42nd street",
+	}, {
+		mode: NumbersAsWritten,
+		inp:  "The 1920s",
+		want: "1920s",
+	}, {
+		mode: NumbersByValue,
+		inp:  "42nd Street",
+		want: "0242 street",
+	}, {
+		mode: NumbersByValue,
+		inp:  "The 1920s",
+		want: "041920s",
+	}, {
+		mode: NumbersByValue,
+		inp:  "007",
+		want: "017",
+	}, {
+		mode: NumbersByValue,
+		inp:  "2 Fast 2 Furious",
+		want: "012 fast 2 furious",
+	}, {
+		mode: NumbersAllByValue,
+		inp:  "2 Fast 2 Furious",
+		want: "012 fast 012 furious",
+	}, {
+		mode: NumbersAllByValue,
+		inp:  "Symphony No. 9",
+		want: "symphony no 019",
+	}, {
+		mode: NumbersAllByValue,
+		inp:  "Hits of the 1990s",
+		want: "hits of the 041990s",
+	}, {
+		mode: NumbersAllByValue,
+		inp:  "The 39 Steps",
+		want: "0239 steps",
+	}, {
+		mode: NumbersAllByValue,
+		inp:  "Part 3.5",
+		want: "part 013.5",
+	}, {
+		mode: NumbersAllByValue,
+		inp:  "Vol. 1,000.25 (Reprint)",
+		want: "vol 041000.25 reprint",
+	}, {
+		mode: NumbersAllByValue,
+		inp:  "3.5 Part",
+		want: "013.5 part",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(WithNumberMode(tc.mode)).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNumbersByValueOrder(t *testing.T) {
+	x := []string{"Aardvark", "1917", "42nd Street", "9 to 5", "The 1920s"}
+	SortWith(x, WithNumberMode(NumbersByValue))
+	want := []string{"9 to 5", "42nd Street", "1917", "The 1920s", "Aardvark"}
+	for i := range want {
+		if x[i] != want[i] {
+			t.Fatalf("got %q, want %q", x, want)
+		}
+	}
+}
+
+func TestNumbersAllByValueOrder(t *testing.T) {
+	x := []string{"Part 10", "100", "Part 9", "10", "9", "Part 9.5", "Part 100", "2 Fast 2 Furious", "Part Two"}
+	SortWith(x, WithNumberMode(NumbersAllByValue))
+	want := []string{"2 Fast 2 Furious", "9", "10", "100", "Part 9", "Part 9.5", "Part 10", "Part 100", "Part Two"}
+	for i := range want {
+		if x[i] != want[i] {
+			t.Fatalf("got %q, want %q", x, want)
+		}
+	}
+}
+
+func TestPaddedNumbers(t *testing.T) {
+	cases := []struct {
+		opts      []Option
+		inp, want string
+	}{{
+		opts: []Option{WithNumberMode(NumbersByValue), WithPaddedNumbers(0)},
+		inp:  "42nd Street",
+		want: "00000000000000000042 street",
+	}, {
+		opts: []Option{WithNumberMode(NumbersByValue), WithPaddedNumbers(4)},
+		inp:  "007",
+		want: "0007",
+	}, {
+		opts: []Option{WithNumberMode(NumbersByValue), WithPaddedNumbers(4)},
+		inp:  "The 1920s",
+		want: "1920s",
+	}, {
+		opts: []Option{WithNumberMode(NumbersByValue), WithPaddedNumbers(4)},
+		inp:  "3.14 Reasons",
+		want: "0003.14 reasons",
+	}, {
+		opts: []Option{WithNumberMode(NumbersAllByValue), WithPaddedNumbers(4)},
+		inp:  "2 Fast 2 Furious",
+		want: "0002 fast 0002 furious",
+	}, {
+		opts: []Option{WithNumberMode(NumbersByValue), WithPaddedNumbers(4)},
+		inp:  "0",
+		want: "0000",
+	}, {
+		opts: []Option{WithPaddedNumbers(4)},
+		inp:  "42nd Street",
+		want: "0042 street",
+	}, {
+		opts: []Option{WithNumberMode(NumbersAsWritten), WithPaddedNumbers(4)},
+		inp:  "42nd Street",
+		want: "42nd street",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(tc.opts...).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPaddedNumbersOrder(t *testing.T) {
+	x := []string{"Aardvark", "1917", "42nd Street", "9 to 5", "−40 Degrees", "The 1920s", "3.5 Stars"}
+	SortWith(x, WithNumberMode(NumbersByValue), WithPaddedNumbers(8))
+	want := []string{"−40 Degrees", "3.5 Stars", "9 to 5", "42nd Street", "1917", "The 1920s", "Aardvark"}
+	for i := range want {
+		if x[i] != want[i] {
+			t.Fatalf("got %q, want %q", x, want)
+		}
+	}
+}
+
+func TestNumbersLastOrder(t *testing.T) {
+	x := []string{"Aardvark", "1917", "", "42nd Street", "9 to 5", "Zyzzyva", "The 1920s", "Ωmega"}
+	SortWith(x, WithNumberMode(NumbersLast), WithPlacement(PlaceLast))
+	want := []string{"Aardvark", "Zyzzyva", "Ωmega", "9 to 5", "42nd Street", "1917", "The 1920s", ""}
+	for i := range want {
+		if x[i] != want[i] {
+			t.Fatalf("got %q, want %q", x, want)
+		}
+	}
+}
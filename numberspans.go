@@ -0,0 +1,140 @@
+package bib
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// NumberSpan describes a number in a string,
+// as found by [NumberSpans].
+type NumberSpan struct {
+	// Start and End are the byte offsets of the number as written,
+	// e.g. "1,000" or "42nd" or "1960s" or "$4.99."
+	Start, End int
+
+	// Value is the number's value,
+	// the whole part of a decimal number or fraction,
+	// the first year of a decade,
+	// or the hour of a time of day,
+	// from 0 to 24.
+	// It is negative for a negative number like "−40."
+	Value int64
+
+	// Frac is the fractional part of a decimal number or fraction,
+	// so "3.14" has Value 3 and Frac 0.14,
+	// and "8½" has Value 8 and Frac 0.5.
+	Frac float64
+
+	// Ordinal is true for an ordinal number like "42nd."
+	Ordinal bool
+
+	// Decade is true for a decade like "1960s" or "'60s."
+	Decade bool
+
+	// Currency is the currency symbol before an amount,
+	// like the "$" of "$5."
+	Currency string
+
+	// Clock is true for a time of day like "3:10" or "6:30pm,"
+	// whose hour is Value,
+	// on a 24-hour clock,
+	// and whose minutes are Minute.
+	Clock  bool
+	Minute int
+
+	// Spelled is true for the number that [Key] spells out
+	// (or, with [NumbersByValue], encodes by value):
+	// one at the start of the string,
+	// after any article.
+	// Both numbers of a range like "1914–1918" are spelled.
+	Spelled bool
+}
+
+// NumberSpans finds the numbers in s,
+// in order,
+// so that e.g. a UI can mark the one that is filed as if spelled out,
+// as "42nd Street" is filed under "forty-second street."
+// A number at the start of s is read as [Key] reads it,
+// so "3.14" is a decimal number,
+// "8 1/2" is a single number,
+// "−40" is negative,
+// "$5" is an amount of money,
+// "3:10" is a time of day,
+// and "1914–1918" is a range of two numbers.
+// Elsewhere,
+// digits joined by other characters,
+// as in "1-2-3" or "Catch-22",
+// are separate numbers,
+// except for commas and periods,
+// which join their digits into one number as they do in a key,
+// so "1,000" is one thousand.
+// It uses the default [Keyer] (see [SetDefault]).
+func NumberSpans(s string) []NumberSpan {
+	return Default().NumberSpans(s)
+}
+
+// NumberSpans finds the numbers in s.
+// See [NumberSpans].
+// With [WithResolver], [WithMarkupStripping], or [WithParallelTitles],
+// the offsets are into the resolved string,
+// with its markup removed,
+// or the chosen parallel title.
+func (k *Keyer) NumberSpans(s string) []NumberSpan {
+	var result []NumberSpan
+	k.keySpans(s, nil, &result)
+	spelled := len(result)
+
+	s = k.prepare(s)
+
+	var (
+		chunk   = -1 // the start of the chunk that matches come from
+		matches [][]int
+	)
+	for _, t := range k.split(s) {
+		var ns NumberSpan
+		if m := numRegex.FindStringSubmatch(t.text); m != nil {
+			ns.Ordinal = m[2] != ""
+			ns.Value, _ = strconv.ParseInt(m[1], 10, 64)
+		} else if m := decimalWordRegex.FindStringSubmatch(t.text); m != nil {
+			ns.Value, _ = strconv.ParseInt(m[1], 10, 64)
+			ns.Frac, _ = strconv.ParseFloat("0."+m[2], 64)
+		} else if m := decadeRegex.FindStringSubmatch(t.text); m != nil {
+			ns.Decade = true
+			ns.Value, _ = strconv.ParseInt(m[1], 10, 64)
+		} else {
+			continue
+		}
+
+		// Find where in its chunk the number is written.
+		if t.start != chunk {
+			chunk = t.start
+			matches = writtenNumberRegex.FindAllStringIndex(s[t.start:t.end], -1)
+		}
+		if len(matches) > 0 {
+			ns.Start, ns.End = t.start+matches[0][0], t.start+matches[0][1]
+			matches = matches[1:]
+		} else {
+			ns.Start, ns.End = t.start, t.end
+		}
+
+		if !overlapsAny(ns, result[:spelled]) {
+			result = append(result, ns)
+		}
+	}
+	return result
+}
+
+// overlapsAny tells whether ns overlaps any of spans.
+func overlapsAny(ns NumberSpan, spans []NumberSpan) bool {
+	for _, sp := range spans {
+		if ns.Start < sp.End && ns.End > sp.Start {
+			return true
+		}
+	}
+	return false
+}
+
+// writtenNumberRegex matches a number as written,
+// with any commas or periods inside it
+// and any ordinal or decade suffix.
+var writtenNumberRegex = regexp.MustCompile(`\d(?:[\d,.]*\d)?(?i:st|nd|rd|th|s)?`)
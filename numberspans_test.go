@@ -0,0 +1,105 @@
+package bib
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestNumberSpans(t *testing.T) {
+	cases := []struct {
+		inp  string
+		want []NumberSpan
+	}{{
+		inp: "Gumball Rally",
+	}, {
+		inp:  "42nd Street",
+		want: []NumberSpan{{Start: 0, End: 4, Value: 42, Ordinal: true, Spelled: true}},
+	}, {
+		inp:  "The 1,000 Places",
+		want: []NumberSpan{{Start: 4, End: 9, Value: 1000, Spelled: true}},
+	}, {
+		inp:  "Route 66",
+		want: []NumberSpan{{Start: 6, End: 8, Value: 66}},
+	}, {
+		inp:  "(The '60s)",
+		want: []NumberSpan{{Start: 6, End: 9, Value: 60, Decade: true, Spelled: true}},
+	}, {
+		inp: "1-2-3 and 4TH",
+		want: []NumberSpan{
+			{Start: 0, End: 1, Value: 1, Spelled: true},
+			{Start: 2, End: 3, Value: 2},
+			{Start: 4, End: 5, Value: 3},
+			{Start: 10, End: 13, Value: 4, Ordinal: true},
+		},
+	}, {
+		inp:  "3.14 Reasons",
+		want: []NumberSpan{{Start: 0, End: 4, Value: 3, Frac: 0.14, Spelled: true}},
+	}, {
+		inp:  "Part 3.5",
+		want: []NumberSpan{{Start: 5, End: 8, Value: 35}},
+	}, {
+		inp:  "3:10 to Yuma",
+		want: []NumberSpan{{Start: 0, End: 4, Value: 3, Minute: 10, Clock: true, Spelled: true}},
+	}, {
+		inp:  "6:30pm Tonight",
+		want: []NumberSpan{{Start: 0, End: 6, Value: 18, Minute: 30, Clock: true, Spelled: true}},
+	}, {
+		inp:  "8 1/2 Weeks",
+		want: []NumberSpan{{Start: 0, End: 5, Value: 8, Frac: 0.5, Spelled: true}},
+	}, {
+		inp:  "(8½)",
+		want: []NumberSpan{{Start: 1, End: 4, Value: 8, Frac: 0.5, Spelled: true}},
+	}, {
+		inp:  "−40 Degrees",
+		want: []NumberSpan{{Start: 0, End: 5, Value: -40, Spelled: true}},
+	}, {
+		inp: "1914–1918: The Great War",
+		want: []NumberSpan{
+			{Start: 0, End: 4, Value: 1914, Spelled: true},
+			{Start: 7, End: 11, Value: 1918, Spelled: true},
+		},
+	}, {
+		inp:  "$4.99 Store",
+		want: []NumberSpan{{Start: 0, End: 5, Value: 4, Frac: 0.99, Currency: "$", Spelled: true}},
+	}, {
+		inp:  "£10 Poms",
+		want: []NumberSpan{{Start: 0, End: 4, Value: 10, Currency: "£", Spelled: true}},
+	}, {
+		inp:  "AD 1066",
+		want: []NumberSpan{{Start: 3, End: 7, Value: 1066, Spelled: true}},
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NumberSpans(tc.inp)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNumberSpansAsWritten(t *testing.T) {
+	got := NewKeyer(WithNumberMode(NumbersAsWritten)).NumberSpans("42nd Street")
+	want := []NumberSpan{{Start: 0, End: 4, Value: 42, Ordinal: true}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestNumberSpansByValue(t *testing.T) {
+	got := NewKeyer(WithNumberMode(NumbersByValue)).NumberSpans("3.14 Reasons")
+	want := []NumberSpan{{Start: 0, End: 4, Value: 3, Frac: 0.14, Spelled: true}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestNumberSpansAllByValue(t *testing.T) {
+	got := NewKeyer(WithNumberMode(NumbersAllByValue)).NumberSpans("Part 3.5")
+	want := []NumberSpan{{Start: 5, End: 8, Value: 3, Frac: 0.5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
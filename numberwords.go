@@ -0,0 +1,67 @@
+package bib
+
+import (
+	"strings"
+)
+
+// WithOrdinal makes [NumberWords] spell numbers as ordinals:
+// "forty-second" instead of "forty-two."
+// It has no effect on keys.
+func WithOrdinal() Option {
+	return func(c *config) {
+		c.ordinal = true
+	}
+}
+
+// WithUnhyphenated makes [NumberWords] separate the parts of numbers
+// from twenty-one to ninety-nine
+// into separate words:
+// "forty two" instead of "forty-two."
+// It has no effect on keys.
+func WithUnhyphenated() Option {
+	return func(c *config) {
+		c.unhyphenated = true
+	}
+}
+
+// NumberWords spells out n in English words,
+// as [Key] does with a leading number,
+// e.g. for producing filing cards or test data.
+// The result has one element per word,
+// so 1984 is "nineteen" and "eighty-four."
+// The options are those of the default [Keyer] (see [SetDefault])
+// followed by opts.
+// Besides [WithOrdinal] and [WithUnhyphenated],
+// the ones that matter are those for spelling numbers:
+// [WithNumbering], [WithoutYears], [WithYearRanges], [WithOhYears],
+// [WithBritishAnd], and [WithNegativeWord].
+func NumberWords(n int64, opts ...Option) []string {
+	return Default().NumberWords(n, opts...)
+}
+
+// NumberWords spells out n in English words,
+// according to k's options followed by opts.
+// A negative number begins with "minus"
+// (or the word given with [WithNegativeWord]),
+// except that the result for [math.MinInt64] is unspecified.
+// See [NumberWords].
+func (k *Keyer) NumberWords(n int64, opts ...Option) []string {
+	if len(opts) > 0 {
+		k = k.With(opts...)
+	}
+
+	var words []string
+	if n < 0 {
+		words = append(words, k.negativeWord())
+		n = -n
+	}
+	words = append(words, k.spellInt(n, k.cfg.ordinal, !k.cfg.noYears)...)
+	if !k.cfg.unhyphenated {
+		return words
+	}
+	var result []string
+	for _, w := range words {
+		result = append(result, strings.Split(w, "-")...)
+	}
+	return result
+}
@@ -0,0 +1,110 @@
+package bib
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestNumberWords(t *testing.T) {
+	cases := []struct {
+		k    *Keyer
+		n    int64
+		opts []Option
+		want []string
+	}{{
+		n:    42,
+		want: []string{"forty-two"},
+	}, {
+		n:    42,
+		opts: []Option{WithOrdinal()},
+		want: []string{"forty-second"},
+	}, {
+		n:    1984,
+		want: []string{"nineteen", "eighty-four"},
+	}, {
+		n:    1984,
+		opts: []Option{WithoutYears()},
+		want: []string{"one", "thousand", "nine", "hundred", "eighty-four"},
+	}, {
+		n:    1984,
+		opts: []Option{WithUnhyphenated()},
+		want: []string{"nineteen", "eighty", "four"},
+	}, {
+		n:    2001,
+		want: []string{"two", "thousand", "one"},
+	}, {
+		n:    1907,
+		opts: []Option{WithOhYears()},
+		want: []string{"nineteen", "oh", "seven"},
+	}, {
+		n:    101,
+		opts: []Option{WithBritishAnd()},
+		want: []string{"one", "hundred", "and", "one"},
+	}, {
+		k:    NewKeyer(WithNumbering(NumberingIndian)),
+		n:    500000,
+		want: []string{"five", "lakh"},
+	}, {
+		k:    NewKeyer(WithNumbering(NumberingIndian)),
+		n:    1917,
+		opts: []Option{WithoutYears(), WithOrdinal()},
+		want: []string{"one", "thousand", "nine", "hundred", "seventeenth"},
+	}, {
+		n:    -40,
+		want: []string{"minus", "forty"},
+	}, {
+		n:    -40,
+		opts: []Option{WithNegativeWord("negative")},
+		want: []string{"negative", "forty"},
+	}, {
+		n:    -2,
+		opts: []Option{WithOrdinal()},
+		want: []string{"minus", "second"},
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			k := tc.k
+			if k == nil {
+				k = Default()
+			}
+			if got := k.NumberWords(tc.n, tc.opts...); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOrdinalWords(t *testing.T) {
+	want := []string{
+		"zeroth", "first", "second", "third", "fourth",
+		"fifth", "sixth", "seventh", "eighth", "ninth",
+		"tenth", "eleventh", "twelfth", "thirteenth", "fourteenth",
+		"fifteenth", "sixteenth", "seventeenth", "eighteenth", "nineteenth",
+		"twentieth", "twenty-first",
+	}
+	for n, w := range want {
+		if got := NumberWords(int64(n), WithOrdinal()); !reflect.DeepEqual(got, []string{w}) {
+			t.Errorf("%d: got %q, want %q", n, got, w)
+		}
+	}
+
+	cases := []struct {
+		n    int64
+		want []string
+	}{
+		{n: 112, want: []string{"one", "hundred", "twelfth"}},
+		{n: 1012, want: []string{"one", "thousand", "twelfth"}},
+		{n: 92, want: []string{"ninety-second"}},
+	}
+	for _, tc := range cases {
+		if got := NumberWords(tc.n, WithOrdinal()); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%d: got %q, want %q", tc.n, got, tc.want)
+		}
+	}
+
+	if got, want := Key("The 12th Man"), "twelfth man"; got != want {
+		t.Errorf(`got "%s", want "%s"`, got, want)
+	}
+}
@@ -0,0 +1,176 @@
+// Package opds sorts the entries of OPDS catalog feeds bibliographically.
+//
+// OPDS catalogs are Atom feeds,
+// so this works on other Atom feeds too.
+// Sorting rearranges the feed's entry elements byte for byte,
+// leaving everything else in the document
+// (the feed header, links, whitespace, comments)
+// exactly as it was.
+package opds
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bobg/go-generics/v4/slices"
+
+	"github.com/bobg/bib"
+	"github.com/bobg/bib/internal/xmlsplice"
+)
+
+const atomNS = "http://www.w3.org/2005/Atom"
+
+// Feed is a parsed OPDS (Atom) feed.
+type Feed struct {
+	// Entries are the feed's entries, in document order until [Feed.Sort] is called.
+	// Callers may reorder Entries but must not add or remove any.
+	Entries []*Entry
+
+	src   []byte
+	slots [][2]int // byte ranges of the original entries in src
+}
+
+// Entry is one entry in a feed.
+type Entry struct {
+	// Title is the text of the entry's title element.
+	Title string
+
+	// XML is the entry element, verbatim from the source document.
+	XML []byte
+}
+
+// SortTitle returns the bibliographic sort key for the entry's title.
+func (e *Entry) SortTitle() string {
+	return bib.Key(e.Title)
+}
+
+// Parse parses an OPDS feed.
+func Parse(data []byte) (*Feed, error) {
+	f := &Feed{src: data}
+
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	depth := 0
+	for {
+		start := dec.InputOffset()
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing feed: %w", err)
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if depth == 0 && !isAtom(tok.Name, "feed") {
+				return nil, fmt.Errorf("root element is %s, not an Atom feed", tok.Name.Local)
+			}
+			if depth == 1 && isAtom(tok.Name, "entry") {
+				title, err := readEntry(dec)
+				if err != nil {
+					return nil, err
+				}
+				end := int(dec.InputOffset())
+				f.Entries = append(f.Entries, &Entry{Title: title, XML: data[start:end]})
+				f.slots = append(f.slots, [2]int{int(start), end})
+				continue
+			}
+			depth++
+
+		case xml.EndElement:
+			depth--
+		}
+	}
+
+	return f, nil
+}
+
+func isAtom(name xml.Name, local string) bool {
+	return name.Space == atomNS && name.Local == local
+}
+
+// readEntry consumes tokens through the end of the current entry element
+// and returns the text of its title.
+func readEntry(dec *xml.Decoder) (string, error) {
+	var (
+		title      strings.Builder
+		titleType  string
+		depth      = 1
+		titleDepth = 0 // nonzero while inside the title element
+		sawTitle   bool
+	)
+	for depth > 0 {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", fmt.Errorf("parsing entry: %w", err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 2 && !sawTitle && isAtom(tok.Name, "title") {
+				titleDepth, sawTitle = depth, true
+				for _, attr := range tok.Attr {
+					if attr.Name.Local == "type" {
+						titleType = attr.Value
+					}
+				}
+			}
+
+		case xml.EndElement:
+			if depth == titleDepth {
+				titleDepth = 0
+			}
+			depth--
+
+		case xml.CharData:
+			if titleDepth > 0 {
+				title.Write(tok)
+			}
+		}
+	}
+
+	s := title.String()
+	if titleType == "html" {
+		// The markup arrives as escaped text; drop the tags.
+		s = tagRegex.ReplaceAllString(s, "")
+	}
+	return strings.TrimSpace(s), nil
+}
+
+var tagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// Sort sorts the feed's entries bibliographically by title.
+func (f *Feed) Sort() {
+	keys := slices.Map(f.Entries, (*Entry).SortTitle)
+	slices.KeyedSort(f.Entries, sort.StringSlice(keys))
+}
+
+// Bytes returns the feed document
+// with its entries in their current order.
+// Each entry occupies the place in the document
+// where the entry at the same position originally appeared.
+func (f *Feed) Bytes() []byte {
+	return xmlsplice.Splice(f.src, f.slots, slices.Map(f.Entries, func(e *Entry) []byte { return e.XML }))
+}
+
+// Sort reads an OPDS feed from r,
+// sorts its entries bibliographically by title,
+// and writes the result to w.
+func Sort(r io.Reader, w io.Writer) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading feed: %w", err)
+	}
+	f, err := Parse(data)
+	if err != nil {
+		return err
+	}
+	f.Sort()
+	_, err = w.Write(f.Bytes())
+	return err
+}
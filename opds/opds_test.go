@@ -0,0 +1,79 @@
+package opds
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const testFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/terms/">
+  <id>urn:uuid:catalog</id>
+  <title>New Arrivals</title>
+  <!-- newest first -->
+  <entry>
+    <title>The Gumball Rally</title>
+    <id>urn:1</id>
+  </entry>
+  <entry>
+    <title type="html">&lt;em&gt;42nd&lt;/em&gt; Street</title>
+    <id>urn:2</id>
+  </entry>
+  <entry>
+    <id>urn:3</id>
+    <title>An Apple a Day</title>
+  </entry>
+  <link rel="next" href="page2.xml"/>
+</feed>
+`
+
+func TestSort(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := Sort(strings.NewReader(testFeed), buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/terms/">
+  <id>urn:uuid:catalog</id>
+  <title>New Arrivals</title>
+  <!-- newest first -->
+  <entry>
+    <id>urn:3</id>
+    <title>An Apple a Day</title>
+  </entry>
+  <entry>
+    <title type="html">&lt;em&gt;42nd&lt;/em&gt; Street</title>
+    <id>urn:2</id>
+  </entry>
+  <entry>
+    <title>The Gumball Rally</title>
+    <id>urn:1</id>
+  </entry>
+  <link rel="next" href="page2.xml"/>
+</feed>
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestParse(t *testing.T) {
+	f, err := Parse([]byte(testFeed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(f.Entries))
+	}
+	if got := f.Entries[1].Title; got != "42nd Street" {
+		t.Errorf(`got title "%s", want "42nd Street"`, got)
+	}
+	if got := string(f.Bytes()); got != testFeed {
+		t.Errorf("unsorted round trip changed the feed:\n%s", got)
+	}
+
+	if _, err := Parse([]byte(`<rss version="2.0"><channel/></rss>`)); err == nil {
+		t.Error("got no error parsing a non-Atom document")
+	}
+}
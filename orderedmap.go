@@ -0,0 +1,100 @@
+package bib
+
+import (
+	"iter"
+	"sort"
+
+	"github.com/bobg/go-generics/v4/slices"
+)
+
+// OrderedMap is a map from titles (or other strings) to values
+// that iterates in bibliographic order of its titles,
+// computing the key of each title only once,
+// when it is added.
+// Titles are distinct only if they are different strings,
+// so "The Heat" and "Heat" are separate entries,
+// in the order in which they were added
+// (unless the Keyer has a tie-breaking function; see [WithTieBreak]).
+//
+// The zero OrderedMap is empty and ready to use,
+// with the default [Keyer] (see [SetDefault]).
+// An OrderedMap is not safe for concurrent use.
+type OrderedMap[V any] struct {
+	k      *Keyer
+	vals   map[string]V
+	titles []KeyedString
+}
+
+// NewOrderedMap produces an empty [OrderedMap] ordered by k.
+// If k is nil, [Default] is used.
+func NewOrderedMap[V any](k *Keyer) *OrderedMap[V] {
+	if k == nil {
+		k = Default()
+	}
+	return &OrderedMap[V]{k: k}
+}
+
+// Len tells the number of entries in m.
+func (m *OrderedMap[V]) Len() int {
+	return len(m.titles)
+}
+
+// Get returns the value for title in m
+// and whether there is one.
+func (m *OrderedMap[V]) Get(title string) (V, bool) {
+	v, ok := m.vals[title]
+	return v, ok
+}
+
+// Set sets the value for title in m,
+// adding title if it is not already present.
+func (m *OrderedMap[V]) Set(title string, v V) {
+	if _, ok := m.vals[title]; !ok {
+		if m.k == nil {
+			m.k = Default()
+		}
+		e := KeyedString{Orig: title, Key: m.k.Key(title)}
+		i := sort.Search(len(m.titles), func(i int) bool { return m.k.compareKeyed(m.titles[i], e) > 0 })
+		m.titles = slices.Insert(m.titles, i, e)
+	}
+	if m.vals == nil {
+		m.vals = make(map[string]V)
+	}
+	m.vals[title] = v
+}
+
+// Delete removes title from m
+// and tells whether it was present.
+func (m *OrderedMap[V]) Delete(title string) bool {
+	if _, ok := m.vals[title]; !ok {
+		return false
+	}
+	delete(m.vals, title)
+
+	key := m.k.Key(title)
+	i := sort.Search(len(m.titles), func(i int) bool { return m.titles[i].Key >= key })
+	for m.titles[i].Orig != title {
+		i++
+	}
+	m.titles = slices.Delete(m.titles, i, i+1)
+	return true
+}
+
+// All returns an iterator over the titles and values in m,
+// in bibliographic order of the titles.
+// The map must not be changed during iteration,
+// except by setting the values of titles already present.
+func (m *OrderedMap[V]) All() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		for _, e := range m.titles {
+			if !yield(e.Orig, m.vals[e.Orig]) {
+				return
+			}
+		}
+	}
+}
+
+// Titles returns the titles in m in bibliographic order.
+func (m *OrderedMap[V]) Titles() []string {
+	return Origs(m.titles)
+}
@@ -0,0 +1,62 @@
+package bib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrderedMap(t *testing.T) {
+	var m OrderedMap[int]
+	m.Set("Zulu", 1)
+	m.Set("The Heat", 2)
+	m.Set("Alpha", 3)
+	m.Set("Heat", 4)
+	m.Set("Mike", 5)
+	m.Set("Alpha", 6)
+
+	if m.Len() != 5 {
+		t.Errorf("got length %d, want 5", m.Len())
+	}
+	if v, ok := m.Get("Alpha"); v != 6 || !ok {
+		t.Errorf("got %d, %v for Alpha; want 6, true", v, ok)
+	}
+	if _, ok := m.Get("An Alpha"); ok {
+		t.Error("found An Alpha")
+	}
+
+	type pair struct {
+		title string
+		v     int
+	}
+	var got []pair
+	for title, v := range m.All() {
+		got = append(got, pair{title: title, v: v})
+	}
+	want := []pair{{"Alpha", 6}, {"The Heat", 2}, {"Heat", 4}, {"Mike", 5}, {"Zulu", 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if m.Delete("A Mike") {
+		t.Error("deleted A Mike")
+	}
+	if !m.Delete("Heat") {
+		t.Error("did not delete Heat")
+	}
+	wantTitles := []string{"Alpha", "The Heat", "Mike", "Zulu"}
+	if got := m.Titles(); !reflect.DeepEqual(got, wantTitles) {
+		t.Errorf("after deleting, got %q, want %q", got, wantTitles)
+	}
+}
+
+func TestNewOrderedMap(t *testing.T) {
+	m := NewOrderedMap[string](NewKeyer(WithArticles()))
+	m.Set("The Heat", "x")
+	m.Set("Heat", "y")
+	m.Set("Mike", "z")
+
+	want := []string{"Heat", "Mike", "The Heat"}
+	if got := m.Titles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,69 @@
+package bib
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WithParallelTitles makes a [Keyer] key only one of the parallel titles
+// in input of the form "Title proper = Parallel title,"
+// as in catalog records that give a title in more than one language,
+// instead of running them all together.
+// The titles are separated by an equals sign with a space on each side,
+// per ISBD.
+//
+// The choose function receives the parallel titles
+// (when there are at least two)
+// and returns the index of the one to key.
+// If choose is nil,
+// or returns an index that is out of range,
+// the first title (the title proper) is keyed.
+// See also [ParallelInScript].
+func WithParallelTitles(choose func(titles []string) int) Option {
+	return func(c *config) {
+		c.parallel = true
+		c.chooseParallel = choose
+	}
+}
+
+// ParallelInScript produces a function for [WithParallelTitles]
+// that chooses the first of the parallel titles
+// whose letters are mostly in the given script,
+// such as [unicode.Cyrillic],
+// or the title proper if there is none.
+func ParallelInScript(script *unicode.RangeTable) func([]string) int {
+	return func(titles []string) int {
+		for i, t := range titles {
+			var in, out int
+			for _, r := range t {
+				switch {
+				case !unicode.IsLetter(r):
+				case unicode.Is(script, r):
+					in++
+				default:
+					out++
+				}
+			}
+			if in > out {
+				return i
+			}
+		}
+		return 0
+	}
+}
+
+// parallelTitle returns the parallel title in s chosen by k's options.
+func (k *Keyer) parallelTitle(s string) string {
+	titles := strings.Split(s, " = ")
+	if len(titles) < 2 {
+		return s
+	}
+	i := 0
+	if k.cfg.chooseParallel != nil {
+		i = k.cfg.chooseParallel(titles)
+	}
+	if i < 0 || i >= len(titles) {
+		i = 0
+	}
+	return titles[i]
+}
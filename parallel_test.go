@@ -0,0 +1,51 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+	"unicode"
+)
+
+func TestParallelTitles(t *testing.T) {
+	cases := []struct {
+		choose func([]string) int
+		inp    string
+		want   string
+	}{{
+		inp:  "The Bald Soprano = La cantatrice chauve",
+		want: "bald soprano",
+	}, {
+		choose: func([]string) int { return 1 },
+		inp:    "The Bald Soprano = La cantatrice chauve",
+		want:   "la cantatrice chauve",
+	}, {
+		choose: func([]string) int { return 7 },
+		inp:    "The Bald Soprano = La cantatrice chauve",
+		want:   "bald soprano",
+	}, {
+		choose: ParallelInScript(unicode.Cyrillic),
+		inp:    "The Master and Margarita = Мастер и Маргарита",
+		want:   "мастер и маргарита",
+	}, {
+		choose: ParallelInScript(unicode.Greek),
+		inp:    "The Master and Margarita = Мастер и Маргарита",
+		want:   "master and margarita",
+	}, {
+		inp:  "E=mc2",
+		want: "emc2",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(WithParallelTitles(tc.choose)).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+
+	// Without the option, the parallel titles run together.
+	if got, want := Key("The Bald Soprano = La cantatrice chauve"), "bald soprano la cantatrice chauve"; got != want {
+		t.Errorf(`got "%s" without WithParallelTitles, want "%s"`, got, want)
+	}
+}
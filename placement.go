@@ -0,0 +1,40 @@
+package bib
+
+import "unicode/utf8"
+
+// Placement says where input with nothing to file on sorts:
+// input that is empty,
+// all whitespace,
+// or (unless [WithSpelledSymbols] is in effect) all symbols and punctuation.
+type Placement int
+
+const (
+	// PlaceFirst sorts such input before everything else.
+	// This is the default.
+	PlaceFirst Placement = iota
+
+	// PlaceLast sorts such input after everything else.
+	PlaceLast
+)
+
+// lastKey sorts after every key made from text,
+// being the largest possible UTF-8 encoding of a single character.
+var lastKey = string(utf8.MaxRune)
+
+// WithPlacement sets where a [Keyer] sorts input with nothing to file on.
+// With [PlaceFirst], the key for empty or all-whitespace input is "".
+// With [PlaceLast], it sorts after all others,
+// and the default bucket for symbol-only input (see [WithSymbolBucket]) does too.
+func WithPlacement(p Placement) Option {
+	return func(c *config) {
+		c.placement = p
+	}
+}
+
+// emptyKey is the key for input with nothing at all to file on.
+func (k *Keyer) emptyKey() string {
+	if k.cfg.placement == PlaceLast {
+		return lastKey
+	}
+	return ""
+}
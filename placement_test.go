@@ -0,0 +1,52 @@
+package bib
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestPlacement(t *testing.T) {
+	cases := []struct {
+		place Placement
+		inp   []string
+		want  []string
+	}{{
+		place: PlaceFirst,
+		inp:   []string{"Zebra", "", "The Aardvark", "   ", "!!!"},
+		want:  []string{"", "   ", "!!!", "The Aardvark", "Zebra"},
+	}, {
+		place: PlaceLast,
+		inp:   []string{"Zebra", "", "The Aardvark", "   ", "!!!"},
+		want:  []string{"The Aardvark", "Zebra", "", "   ", "!!!"},
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			k := NewKeyer(WithPlacement(tc.place))
+			got := append([]string(nil), tc.inp...)
+			sort.SliceStable(got, func(i, j int) bool { return k.Key(got[i]) < k.Key(got[j]) })
+			for j := range tc.want {
+				if got[j] != tc.want[j] {
+					t.Fatalf("got %q, want %q", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestDegenerate(t *testing.T) {
+	inps := []string{"", " ", "\t\n", "!!!", "+/-", "The", "(The)", "&", "\u0301", "\ufe0f", "-"}
+	for i, inp := range inps {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			Key(inp)
+			Less(inp, "x")
+			Sort([]string{inp, "x", inp})
+			PersonKey(inp)
+			FilenameKey(inp)
+			TrackKey(inp)
+			NumericKey(inp)
+			DateKey(inp)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package bib
+
+// Prefixed returns s preceded by its sort key and a tab,
+// for use with Unix tools that sort or join on a field.
+// Sorting such lines with
+//
+//	LC_ALL=C sort -s -t "$(printf '\t')" -k1,1
+//
+// puts them in the same order as [Sort],
+// after which
+//
+//	cut -f2-
+//
+// removes the keys again.
+// (The C locale is needed so that sort compares the keys byte by byte.)
+// Keys never contain tabs or newlines.
+// It uses the default [Keyer] (see [SetDefault]).
+func Prefixed(s string) string {
+	return Default().Prefixed(s)
+}
+
+// Prefixed returns s preceded by its sort key and a tab.
+// See [Prefixed].
+func (k *Keyer) Prefixed(s string) string {
+	return k.Key(s) + "\t" + s
+}
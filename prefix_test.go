@@ -0,0 +1,60 @@
+package bib
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestPrefixed(t *testing.T) {
+	cases := []struct {
+		inp, want string
+	}{{
+		inp:  "The Beatles",
+		want: "beatles\tThe Beatles",
+	}, {
+		inp:  "10 Things I Hate About You",
+		want: "ten things i hate about you\t10 Things I Hate About You",
+	}, {
+		inp:  "Tab\tinside",
+		want: "tab inside\tTab\tinside",
+	}, {
+		inp:  "",
+		want: "\t",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := Prefixed(tc.inp)
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPrefixedOrder(t *testing.T) {
+	// Sorting prefixed lines on their first field and stripping the keys
+	// gives the same order as Sort.
+	inp := []string{"Zebra", "The Aardvark", "10 Cats", "A Tale", "!!!"}
+
+	want := append([]string(nil), inp...)
+	Sort(want)
+
+	lines := make([]string, len(inp))
+	for i, s := range inp {
+		lines[i] = Prefixed(s)
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		ki, _, _ := strings.Cut(lines[i], "\t")
+		kj, _, _ := strings.Cut(lines[j], "\t")
+		return ki < kj
+	})
+	for i, line := range lines {
+		_, got, _ := strings.Cut(line, "\t")
+		if got != want[i] {
+			t.Fatalf("at %d got %q, want %q", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package bib
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var presets = struct {
+	sync.RWMutex
+	m map[string][]Option
+}{
+	m: map[string][]Option{
+		"default": nil,
+
+		// Chicago Manual of Style indexing:
+		// letter by letter,
+		// with numerals alphabetized as if spelled out
+		// and "Mc" and "Mac" as written.
+		// Use "cmos-subentry" for subentries.
+		"cmos":          {WithLetterByLetter()},
+		"cmos-subentry": {WithLetterByLetter(), WithSubentryFiling()},
+
+		// Media libraries.
+		// These give only the rough order of the applications' default sorting,
+		// which ignores a leading "a," "an," or "the"
+		// but does not spell out numbers.
+		// They do not reproduce the applications' sort-name rules
+		// for punctuation, non-English articles, and the like,
+		// and their keys are not the sort names the applications store
+		// (for Calibre's, see the calibre package).
+		// Calibre (without its numeric_collation tweak) compares digits as text,
+		// so "10" precedes "9."
+		// iTunes and Plex both put numbers first, in numeric order,
+		// so their presets are the same.
+		"calibre": {WithNumberMode(NumbersAsWritten)},
+		"itunes":  {WithNumberMode(NumbersByValue)},
+		"plex":    {WithNumberMode(NumbersByValue)},
+	},
+}
+
+// RegisterPreset registers a named bundle of options,
+// such as "ala1980" or "letter-by-letter-de,"
+// so that it can be selected at runtime with [PresetKeyer],
+// e.g. from a configuration file or a command-line flag.
+// It is typically called from an init function.
+//
+// RegisterPreset panics if name is empty or already registered.
+// The preset "default," with no options, is always registered,
+// as are "cmos" and "cmos-subentry"
+// for the Chicago Manual of Style's index headings and subentries
+// (see [WithLetterByLetter] and [WithSubentryFiling]),
+// and "calibre," "itunes," and "plex,"
+// which order titles roughly as those applications do by default
+// but do not reproduce their sort names
+// (see [WithNumberMode]).
+func RegisterPreset(name string, opts ...Option) {
+	if name == "" {
+		panic("bib: RegisterPreset with empty name")
+	}
+
+	presets.Lock()
+	defer presets.Unlock()
+
+	if _, ok := presets.m[name]; ok {
+		panic(fmt.Sprintf("bib: RegisterPreset called twice for %s", name))
+	}
+	presets.m[name] = append([]Option(nil), opts...)
+}
+
+// ErrUnknownPreset is the error returned by [PresetKeyer] for a name that is not registered.
+var ErrUnknownPreset = errors.New("unknown preset")
+
+// PresetKeyer returns a new [Keyer] with the options of the named preset.
+// See [RegisterPreset].
+func PresetKeyer(name string) (*Keyer, error) {
+	presets.RLock()
+	opts, ok := presets.m[name]
+	presets.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", name, ErrUnknownPreset)
+	}
+	return NewKeyer(opts...), nil
+}
+
+// Presets returns the names of the registered presets, sorted.
+func Presets() []string {
+	presets.RLock()
+	defer presets.RUnlock()
+
+	names := make([]string, 0, len(presets.m))
+	for name := range presets.m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,78 @@
+package bib
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestPresets(t *testing.T) {
+	RegisterPreset("test-spaced", WithInitialisms(InitialismsSpaced), WithMaxBytes(5))
+
+	k, err := PresetKeyer("test-spaced")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := k.Key("U.S.A. Today"), "u s a"; got != want {
+		t.Errorf(`got "%s", want "%s"`, got, want)
+	}
+
+	k, err = PresetKeyer("default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := k.Key("U.S.A. Today"), "usa today"; got != want {
+		t.Errorf(`got "%s", want "%s"`, got, want)
+	}
+
+	if _, err := PresetKeyer("nonesuch"); !errors.Is(err, ErrUnknownPreset) {
+		t.Errorf("got error %v, want ErrUnknownPreset", err)
+	}
+
+	names := Presets()
+	if !slices.Contains(names, "default") || !slices.Contains(names, "test-spaced") || !slices.IsSorted(names) {
+		t.Errorf("got presets %v", names)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a preset twice did not panic")
+		}
+	}()
+	RegisterPreset("test-spaced")
+}
+
+func TestMediaPresets(t *testing.T) {
+	inp := []string{"The Zoo", "9 to 5", "Alpha", "10 Things", "A Beta"}
+
+	cases := []struct {
+		preset string
+		want   []string
+	}{{
+		preset: "default",
+		want:   []string{"Alpha", "A Beta", "9 to 5", "10 Things", "The Zoo"},
+	}, {
+		preset: "calibre",
+		want:   []string{"10 Things", "9 to 5", "Alpha", "A Beta", "The Zoo"},
+	}, {
+		preset: "itunes",
+		want:   []string{"9 to 5", "10 Things", "Alpha", "A Beta", "The Zoo"},
+	}, {
+		preset: "plex",
+		want:   []string{"9 to 5", "10 Things", "Alpha", "A Beta", "The Zoo"},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.preset, func(t *testing.T) {
+			k, err := PresetKeyer(tc.preset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := slices.Clone(inp)
+			k.Sort(got)
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package bib
+
+import (
+	"context"
+)
+
+// Progress is the progress of a sort,
+// as reported to the function given with [WithProgress].
+type Progress struct {
+	// Total is the number of strings being sorted.
+	Total int
+
+	// Keyed is the number of strings whose keys have been computed so far.
+	Keyed int
+
+	// Sorted is the fraction of the work of sorting the keyed strings done so far,
+	// from 0 to 1.
+	Sorted float64
+}
+
+// WithProgress makes a [Keyer] call f periodically while sorting,
+// for showing a progress bar during a long sort.
+// The keys of all the strings are computed first,
+// with f called after each batch of them;
+// then the strings are sorted,
+// with f called after each step of that.
+//
+// This affects [Keyer.Sort], [Keyer.SortStable], and [Keyer.SortContext].
+// With it, Keyer.Sort is stable.
+// If the Keyer sorts in more than one goroutine at a time,
+// f may be called concurrently.
+func WithProgress(f func(Progress)) Option {
+	return func(c *config) {
+		c.progress = f
+	}
+}
+
+// sortWithProgress sorts strs with SortContext,
+// reporting progress,
+// if k has a progress function,
+// and tells whether it did.
+func (k *Keyer) sortWithProgress(strs []string) bool {
+	if k.cfg.progress == nil {
+		return false
+	}
+	k.SortContext(context.Background(), strs) // the error can only be from the context
+	return true
+}
@@ -0,0 +1,44 @@
+package bib
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestProgress(t *testing.T) {
+	n := 3*ctxChunk + 5
+	strs := make([]string, n)
+	for i := range strs {
+		strs[i] = fmt.Sprintf("The x%07d", n-i)
+	}
+	want := slices.Clone(strs)
+	Sort(want)
+
+	var progs []Progress
+	k := NewKeyer(WithProgress(func(p Progress) { progs = append(progs, p) }))
+	k.Sort(strs)
+
+	if !reflect.DeepEqual(strs, want) {
+		t.Error("sorting with and without progress disagree")
+	}
+
+	if len(progs) == 0 {
+		t.Fatal("no progress reported")
+	}
+	for i, p := range progs {
+		if p.Total != n {
+			t.Errorf("progress %d: got total %d, want %d", i, p.Total, n)
+		}
+		if i == 0 {
+			continue
+		}
+		if prev := progs[i-1]; p.Keyed < prev.Keyed || p.Sorted < prev.Sorted {
+			t.Errorf("progress %d went backward: %+v after %+v", i, p, prev)
+		}
+	}
+	if last := progs[len(progs)-1]; last.Keyed != n || last.Sorted != 1 {
+		t.Errorf("got final progress %+v, want everything done", last)
+	}
+}
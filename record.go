@@ -0,0 +1,74 @@
+package bib
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bobg/go-generics/v4/slices"
+)
+
+// Record is an item with named fields,
+// such as a catalog entry with "author," "title," and "callnumber" fields.
+type Record map[string]string
+
+// Field says how to sort records by one of their fields.
+type Field struct {
+	// Name is the name of the field in the Record.
+	Name string
+
+	// Key converts the field's value to a sort key.
+	// Typical choices are [Key] (for titles), [PersonKey], [DateKey], [CallNumberKey], [SectionKey], and [NumericKey].
+	// If this is nil, Key is used.
+	Key func(string) string
+}
+
+// RecordKey computes a composite sort key for r
+// from the given fields, in priority order.
+// Comparing composite keys is equivalent to comparing the records field by field.
+// A missing or empty field sorts before any nonempty value.
+func RecordKey(r Record, fields ...Field) string {
+	keys := make([]string, 0, len(fields))
+	for _, f := range fields {
+		keys = append(keys, fieldKey(r, f))
+	}
+
+	// NUL sorts before anything that can appear in a key,
+	// so a key that is a prefix of another still sorts first
+	// regardless of what follows it.
+	return strings.Join(keys, "\x00")
+}
+
+func fieldKey(r Record, f Field) string {
+	val, ok := r[f.Name]
+	if !ok || strings.TrimSpace(val) == "" {
+		return ""
+	}
+	keyFn := f.Key
+	if keyFn == nil {
+		keyFn = Key
+	}
+	return keyFn(val)
+}
+
+// CompareRecords returns a function that compares two records
+// by the given fields, in priority order.
+// The function returns a negative number, zero, or a positive number
+// according to whether the first record sorts before, the same as, or after the second,
+// for use with e.g. [slices.SortFunc].
+func CompareRecords(fields ...Field) func(a, b Record) int {
+	return func(a, b Record) int {
+		for _, f := range fields {
+			if c := strings.Compare(fieldKey(a, f), fieldKey(b, f)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+// SortRecords sorts records by the given fields, in priority order,
+// e.g. by author, then title, then call number.
+func SortRecords(recs []Record, fields ...Field) {
+	keys := slices.Map(recs, func(r Record) string { return RecordKey(r, fields...) })
+	slices.KeyedSort(recs, sort.StringSlice(keys))
+}
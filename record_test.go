@@ -0,0 +1,43 @@
+package bib
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSortRecords(t *testing.T) {
+	recs := []Record{
+		{"id": "1", "author": "Judd Apatow", "title": "The Zoo", "call": "PN1997 .Z6"},
+		{"id": "2", "author": "Apatow, Judd", "title": "An Apple", "call": "PN1997 .A6"},
+		{"id": "3", "author": "Chuck Bail", "title": "The Gumball Rally", "call": "PN1997 .G8"},
+		{"id": "4", "title": "Anonymous Work", "call": "PN1997 .A1"},
+		{"id": "5", "author": "Judd Apatow", "title": "An Apple", "call": "PN1997 .A55"},
+	}
+	fields := []Field{
+		{Name: "author", Key: PersonKey},
+		{Name: "title"},
+		{Name: "call", Key: CallNumberKey},
+	}
+	want := []string{"4", "5", "2", "1", "3"}
+
+	ids := func(recs []Record) []string {
+		var result []string
+		for _, r := range recs {
+			result = append(result, r["id"])
+		}
+		return result
+	}
+
+	sorted := slices.Clone(recs)
+	SortRecords(sorted, fields...)
+	if got := ids(sorted); !reflect.DeepEqual(got, want) {
+		t.Errorf("SortRecords: got %v, want %v", got, want)
+	}
+
+	sorted = slices.Clone(recs)
+	slices.SortFunc(sorted, CompareRecords(fields...))
+	if got := ids(sorted); !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareRecords: got %v, want %v", got, want)
+	}
+}
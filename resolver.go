@@ -0,0 +1,21 @@
+package bib
+
+// WithResolver supplies a function that a [Keyer] applies to its input
+// before doing anything else,
+// so that an application can map variant titles and names
+// to their authorized forms
+// (say, from an authority file or a database)
+// and have all the variants file together.
+// The function should return its input unchanged
+// when it has no authorized form for it.
+//
+// The resolver is consulted for every key,
+// so it should be fast,
+// and it must be safe for concurrent use
+// if the Keyer is.
+// It is not applied to the dictionary given to [WithAliases].
+func WithResolver(resolve func(string) string) Option {
+	return func(c *config) {
+		c.resolver = resolve
+	}
+}
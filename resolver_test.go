@@ -0,0 +1,47 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestResolver(t *testing.T) {
+	authorized := map[string]string{
+		"Alice's Adventures in Wonderland": "Alice in Wonderland",
+		"Twain, Mark":                      "Clemens, Samuel Langhorne",
+	}
+	k := NewKeyer(
+		WithResolver(func(s string) string {
+			if a, ok := authorized[s]; ok {
+				return a
+			}
+			return s
+		}),
+		WithAliases(map[string]string{"Alice's": "Alice"}),
+	)
+
+	cases := []struct {
+		inp  string
+		want string
+	}{{
+		inp:  "Alice's Adventures in Wonderland",
+		want: "alice in wonderland",
+	}, {
+		inp:  "Alice in Wonderland",
+		want: "alice in wonderland",
+	}, {
+		inp:  "Twain, Mark",
+		want: "clemens samuel langhorne",
+	}, {
+		inp:  "Alice's Restaurant",
+		want: "alice restaurant",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got := k.Key(tc.inp); got != tc.want {
+				t.Errorf(`input "%s", got "%s", want "%s"`, tc.inp, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package bib
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// SectionKey converts a section heading,
+// such as "§ 1.2.10 Definitions" or "Chapter 4.3: Results,"
+// to a sort key that orders it first by its section number,
+// component by component,
+// and then by the rest of the heading in [Key] order.
+// So 1.2 precedes 1.2.1, which precedes 1.2.9, which precedes 1.2.10.
+// Subsection letters and parenthesized subdivisions are components too,
+// as in "1.2a" and "12(b)(3)."
+// A leading label ("§," "Section," "Chapter," "Art.," and the like) is ignored.
+// Headings without a section number sort after all those with one,
+// in [Key] order.
+func SectionKey(s string) string {
+	m := sectionRegex.FindStringSubmatchIndex(s)
+	if m == nil {
+		return "~" + Key(s)
+	}
+
+	var comps []string
+	for _, c := range sectionComponentRegex.FindAllString(s[m[2]:m[3]], -1) {
+		if unicode.IsDigit(rune(c[0])) {
+			c = encodeInt(c)
+		}
+		comps = append(comps, c)
+	}
+
+	title := strings.TrimLeftFunc(s[m[1]:], func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	})
+
+	// NUL sorts before anything that can appear in a key,
+	// and "." before any encoded component,
+	// so that 1.2 sorts before 1.2.1 regardless of title.
+	return strings.Join(comps, ".") + "\x00" + Key(title)
+}
+
+var (
+	sectionRegex          = regexp.MustCompile(`^\s*(?:(?:§§?|¶|(?i:sections?|sec\.|chapters?|chap\.|ch\.|articles?|art\.|parts?|pt\.|rules?|clauses?|paragraphs?|para\.))\s*)?(\d+[a-z]?(?:[.\-]\d+[a-z]?|\([0-9a-z]+\))*)`)
+	sectionComponentRegex = regexp.MustCompile(`\d+|[a-z]+`)
+)
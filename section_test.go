@@ -0,0 +1,60 @@
+package bib
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestSectionKey(t *testing.T) {
+	cases := []struct {
+		inp, want string
+	}{{
+		inp:  "§ 1.2.10 Definitions",
+		want: "011.012.0210\x00definitions",
+	}, {
+		inp:  "Chapter 4.3: Results",
+		want: "014.013\x00results",
+	}, {
+		inp:  "Chapter 4. The Storm",
+		want: "014\x00storm",
+	}, {
+		inp:  "12(b)(3) Failure to state a claim",
+		want: "0212.b.013\x00failure to state a claim",
+	}, {
+		inp:  "Sec. 1.2a",
+		want: "011.012.a\x00",
+	}, {
+		inp:  "Introduction",
+		want: "~introduction",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := SectionKey(tc.inp)
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSectionKeyOrder(t *testing.T) {
+	want := []string{
+		"§ 1.2 General",
+		"§ 1.2.1 Scope",
+		"§ 1.2.9 Notice",
+		"§ 1.2.10 Definitions",
+		"§ 1.2a Transition",
+		"§ 1.10 Penalties",
+		"§ 2 Enforcement",
+		"Appendix",
+	}
+	got := []string{want[3], want[7], want[0], want[5], want[2], want[6], want[1], want[4]}
+	sort.Slice(got, func(i, j int) bool { return SectionKey(got[i]) < SectionKey(got[j]) })
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+}
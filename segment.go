@@ -0,0 +1,125 @@
+package bib
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Segmenter splits text in a script written without spaces between words,
+// such as Chinese, Japanese, or Thai,
+// into words.
+type Segmenter interface {
+	// Segment splits s, a run of characters in such scripts, into words.
+	// Concatenating the words should give s.
+	Segment(s string) []string
+}
+
+// WithSegmenter makes a [Keyer] split runs of Chinese, Japanese, Thai, Lao, Khmer, and Burmese text
+// into words using seg,
+// so that they file word by word instead of as one long word.
+// See [NewDictionarySegmenter] for a simple Segmenter.
+// By default, no segmentation is done.
+func WithSegmenter(seg Segmenter) Option {
+	return func(c *config) {
+		c.segmenter = seg
+	}
+}
+
+// isUnspaced tells whether r belongs to a script written without spaces between words.
+func isUnspaced(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Thai, unicode.Lao, unicode.Khmer, unicode.Myanmar)
+}
+
+// segment splits the runs of unspaced scripts in word
+// using k's segmenter.
+func (k *Keyer) segment(word string) []string {
+	if k.cfg.segmenter == nil || strings.IndexFunc(word, isUnspaced) < 0 {
+		return []string{word}
+	}
+
+	var result []string
+	for len(word) > 0 {
+		i := strings.IndexFunc(word, isUnspaced)
+		if i < 0 {
+			return append(result, word)
+		}
+		if i > 0 {
+			result = append(result, word[:i])
+			word = word[i:]
+		}
+		j := strings.IndexFunc(word, func(r rune) bool { return !isUnspaced(r) })
+		if j < 0 {
+			j = len(word)
+		}
+		for _, w := range k.cfg.segmenter.Segment(word[:j]) {
+			if w != "" {
+				result = append(result, w)
+			}
+		}
+		word = word[j:]
+	}
+	return result
+}
+
+// DictionarySegmenter is a [Segmenter] that splits text
+// into the longest words it finds in a dictionary.
+type DictionarySegmenter struct {
+	words  map[string]bool
+	maxLen int // in runes
+}
+
+// NewDictionarySegmenter creates a [DictionarySegmenter] with the given words.
+//
+// It segments text by repeatedly taking the longest dictionary word
+// at the start of what remains.
+// Where no dictionary word matches,
+// a Chinese or Japanese character is taken as a word by itself,
+// and a character in another script is joined with any following ones
+// up to the next dictionary word.
+func NewDictionarySegmenter(words ...string) *DictionarySegmenter {
+	d := &DictionarySegmenter{words: make(map[string]bool)}
+	for _, w := range words {
+		w = strings.ToLower(w)
+		d.words[w] = true
+		d.maxLen = max(d.maxLen, len([]rune(w)))
+	}
+	return d
+}
+
+// Segment implements [Segmenter].
+func (d *DictionarySegmenter) Segment(s string) []string {
+	var (
+		result  []string
+		runes   = []rune(s)
+		pending []rune
+	)
+	flush := func() {
+		if len(pending) > 0 {
+			result = append(result, string(pending))
+			pending = nil
+		}
+	}
+	for i := 0; i < len(runes); {
+		n := min(d.maxLen, len(runes)-i)
+		for ; n > 0; n-- {
+			if d.words[string(runes[i:i+n])] {
+				break
+			}
+		}
+		if n > 0 {
+			flush()
+			result = append(result, string(runes[i:i+n]))
+			i += n
+			continue
+		}
+		if unicode.In(runes[i], unicode.Han, unicode.Hiragana, unicode.Katakana) {
+			flush()
+			result = append(result, string(runes[i]))
+		} else {
+			pending = append(pending, runes[i])
+		}
+		i++
+	}
+	flush()
+	return result
+}
@@ -0,0 +1,74 @@
+package bib
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestDictionarySegmenter(t *testing.T) {
+	d := NewDictionarySegmenter("北京", "北京大学", "大学", "生活", "ภาษา", "ไทย")
+
+	cases := []struct {
+		inp  string
+		want []string
+	}{{
+		inp:  "北京大学",
+		want: []string{"北京大学"},
+	}, {
+		inp:  "北京生活",
+		want: []string{"北京", "生活"},
+	}, {
+		inp:  "我的北京",
+		want: []string{"我", "的", "北京"},
+	}, {
+		inp:  "ภาษาไทย",
+		want: []string{"ภาษา", "ไทย"},
+	}, {
+		inp:  "เรียนภาษาไทย",
+		want: []string{"เรียน", "ภาษา", "ไทย"},
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got := d.Segment(tc.inp); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf(`input "%s", got %q, want %q`, tc.inp, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSegmenter(t *testing.T) {
+	cases := []struct {
+		inp  string
+		seg  Segmenter
+		want string
+	}{{
+		inp:  "北京生活",
+		want: "北京生活",
+	}, {
+		inp:  "北京生活",
+		seg:  NewDictionarySegmenter("北京", "生活"),
+		want: "北京 生活",
+	}, {
+		inp:  "Tokyo東京ガイド",
+		seg:  NewDictionarySegmenter("東京", "ガイド"),
+		want: "tokyo 東京 ガイド",
+	}, {
+		inp:  "The 北京 Story",
+		seg:  NewDictionarySegmenter("北京"),
+		want: "北京 story",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			k := NewKeyer()
+			if tc.seg != nil {
+				k = NewKeyer(WithSegmenter(tc.seg))
+			}
+			if got := k.Key(tc.inp); got != tc.want {
+				t.Errorf(`input "%s", got "%s", want "%s"`, tc.inp, got, tc.want)
+			}
+		})
+	}
+}
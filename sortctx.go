@@ -0,0 +1,120 @@
+package bib
+
+import (
+	"context"
+)
+
+// ctxChunk is how many strings SortContext keys, sorts, or merges
+// between checks of its context.
+const ctxChunk = 1 << 14
+
+// SortContext sorts strs bibliographically, like [SortStable],
+// but checks ctx periodically while computing keys and sorting,
+// returning ctx's error if it is canceled,
+// so that sorting millions of strings can be stopped cleanly.
+// If it returns an error, strs is unchanged.
+// The Keyer's progress function, if any, is called as it goes
+// (see [WithProgress]).
+// It uses the default [Keyer] (see [SetDefault]).
+func SortContext(ctx context.Context, strs []string) error {
+	return Default().SortContext(ctx, strs)
+}
+
+// SortContext sorts strs by their keys,
+// stopping if ctx is canceled.
+// See [SortContext].
+func (k *Keyer) SortContext(ctx context.Context, strs []string) error {
+	n := len(strs)
+	p := progressReporter{f: k.cfg.progress, prog: Progress{Total: n}}
+
+	keyed := make([]KeyedString, n)
+	for lo := 0; lo < n; lo += ctxChunk {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hi := min(lo+ctxChunk, n)
+		for i := lo; i < hi; i++ {
+			keyed[i] = KeyedString{Orig: strs[i], Key: k.Key(strs[i])}
+		}
+		p.prog.Keyed = hi
+		p.report()
+	}
+
+	// Sort runs of ctxChunk strings,
+	// then merge adjacent runs until there is one.
+	// Each of those passes handles all n strings.
+	for width := ctxChunk; width < n; width *= 2 {
+		p.passes++
+	}
+	for lo := 0; lo < n; lo += ctxChunk {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hi := min(lo+ctxChunk, n)
+		k.sortKeyed(keyed[lo:hi])
+		p.sorted(hi - lo)
+	}
+	buf := make([]KeyedString, n)
+	for width := ctxChunk; width < n; width *= 2 {
+		for lo := 0; lo < n; lo += 2 * width {
+			mid, hi := min(lo+width, n), min(lo+2*width, n)
+			if err := k.mergeKeyed(ctx, buf[lo:hi], keyed[lo:mid], keyed[mid:hi]); err != nil {
+				return err
+			}
+			p.sorted(hi - lo)
+		}
+		keyed, buf = buf, keyed
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for i, e := range keyed {
+		strs[i] = e.Orig
+	}
+	return nil
+}
+
+// mergeKeyed merges a and b, which must each be sorted, into dst,
+// taking elements from a first where keys are equal.
+// It checks ctx periodically.
+func (k *Keyer) mergeKeyed(ctx context.Context, dst, a, b []KeyedString) error {
+	var i, j int
+	for n := range dst {
+		if n%ctxChunk == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		if j == len(b) || (i < len(a) && k.compareKeyed(b[j], a[i]) >= 0) {
+			dst[n] = a[i]
+			i++
+		} else {
+			dst[n] = b[j]
+			j++
+		}
+	}
+	return nil
+}
+
+// progressReporter reports the progress of SortContext
+// to the function given with WithProgress.
+type progressReporter struct {
+	f      func(Progress)
+	prog   Progress
+	passes int // the number of merge passes
+	done   int // the number of strings sorted or merged, over all passes
+}
+
+// sorted records that n more strings have been sorted or merged.
+func (p *progressReporter) sorted(n int) {
+	p.done += n
+	p.prog.Sorted = float64(p.done) / float64(p.prog.Total*(1+p.passes))
+	p.report()
+}
+
+func (p *progressReporter) report() {
+	if p.f != nil {
+		p.f(p.prog)
+	}
+}
@@ -0,0 +1,46 @@
+package bib
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestSortContext(t *testing.T) {
+	// Enough strings for several runs and merge passes.
+	n := 5*ctxChunk + 17
+	strs := make([]string, n)
+	for i := range strs {
+		// Reverse order, with equal keys in every pair.
+		if i%2 == 0 {
+			strs[i] = fmt.Sprintf("x%07d", (n-i)/2)
+		} else {
+			strs[i] = fmt.Sprintf("The x%07d", (n-i)/2)
+		}
+	}
+	want := slices.Clone(strs)
+	SortStable(want)
+
+	if err := SortContext(context.Background(), strs); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(strs, want) {
+		t.Error("SortContext and SortStable disagree")
+	}
+}
+
+func TestSortContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	strs := []string{"Zulu", "Alpha"}
+	if err := SortContext(ctx, strs); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+	if want := []string{"Zulu", "Alpha"}; !reflect.DeepEqual(strs, want) {
+		t.Errorf("got %q, want %q", strs, want)
+	}
+}
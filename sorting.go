@@ -0,0 +1,452 @@
+package bib
+
+import (
+	"container/heap"
+	"iter"
+	"sort"
+
+	"github.com/bobg/go-generics/v4/slices"
+)
+
+// SortFunc sorts items bibliographically
+// by the strings that keyOf returns for them
+// (e.g. the titles of books),
+// computing the key of each item only once.
+// It uses the default [Keyer] (see [SetDefault]).
+func SortFunc[T any](items []T, keyOf func(T) string) {
+	k := Default()
+	keyed := slices.Map(items, func(item T) KeyedString {
+		s := keyOf(item)
+		return KeyedString{Orig: s, Key: k.Key(s)}
+	})
+	sort.Sort(keyedSorter[T]{k: k, items: items, keyed: keyed})
+}
+
+// SortStable sorts strs bibliographically,
+// keeping strings whose keys are equal
+// (such as "The Heat" and "Heat")
+// in their original order.
+// It uses the default [Keyer] (see [SetDefault]).
+func SortStable(strs []string) {
+	Default().SortStable(strs)
+}
+
+// SortStable sorts strs by their keys,
+// keeping strings whose keys are equal in their original order.
+func (k *Keyer) SortStable(strs []string) {
+	if k.sortWithProgress(strs) {
+		return
+	}
+	keyed := k.MakeKeyed(strs)
+	k.sortKeyed(keyed)
+	for i, e := range keyed {
+		strs[i] = e.Orig
+	}
+}
+
+// SortDesc sorts strs in reverse bibliographic order (Z to A).
+// Unlike sorting and then reversing,
+// it keeps strings whose keys are equal in their original order.
+// It uses the default [Keyer] (see [SetDefault]).
+func SortDesc(strs []string) {
+	Default().SortDesc(strs)
+}
+
+// SortDesc sorts strs in reverse order by their keys,
+// keeping strings whose keys are equal in their original order.
+func (k *Keyer) SortDesc(strs []string) {
+	keyed := k.MakeKeyed(strs)
+	sort.SliceStable(keyed, func(i, j int) bool { return k.compareKeyed(keyed[j], keyed[i]) < 0 })
+	for i, e := range keyed {
+		strs[i] = e.Orig
+	}
+}
+
+// IsSorted tells whether strs is in bibliographic order.
+// It uses the default [Keyer] (see [SetDefault]).
+func IsSorted(strs []string) bool {
+	return Default().IsSorted(strs)
+}
+
+// IsSorted tells whether strs is in order by their keys.
+func (k *Keyer) IsSorted(strs []string) bool {
+	var prev KeyedString
+	for i, s := range strs {
+		cur := KeyedString{Orig: s, Key: k.Key(s)}
+		if i > 0 && k.compareKeyed(prev, cur) > 0 {
+			return false
+		}
+		prev = cur
+	}
+	return true
+}
+
+// Search searches strs,
+// which must be in bibliographic order (as by [Sort]),
+// for a string with the same key as target.
+// It returns the position of the first such string and true,
+// or, if there is none,
+// the position where target would be inserted and false.
+// It computes the keys of only O(log n) strings.
+// It uses the default [Keyer] (see [SetDefault]).
+func Search(strs []string, target string) (int, bool) {
+	return Default().Search(strs, target)
+}
+
+// Search searches strs,
+// which must be in order by their keys,
+// for a string with the same key as target.
+// See [Search].
+func (k *Keyer) Search(strs []string, target string) (int, bool) {
+	key := k.Key(target)
+	i := sort.Search(len(strs), func(i int) bool { return k.Key(strs[i]) >= key })
+	return i, i < len(strs) && k.Key(strs[i]) == key
+}
+
+// Merge merges a and b,
+// which must each be in bibliographic order (as by [Sort]),
+// into a new slice in bibliographic order.
+// Where strings in a and b have equal keys,
+// those from a come first.
+// It takes linear time,
+// computing the key of each string only once.
+// It uses the default [Keyer] (see [SetDefault]).
+func Merge(a, b []string) []string {
+	return Default().Merge(a, b)
+}
+
+// Merge merges a and b,
+// which must each be in order by their keys.
+// See [Merge].
+func (k *Keyer) Merge(a, b []string) []string {
+	var (
+		result = make([]string, 0, len(a)+len(b))
+		i, j   int
+		ka, kb KeyedString
+	)
+	if len(a) > 0 {
+		ka = KeyedString{Orig: a[0], Key: k.Key(a[0])}
+	}
+	if len(b) > 0 {
+		kb = KeyedString{Orig: b[0], Key: k.Key(b[0])}
+	}
+	for i < len(a) && j < len(b) {
+		if k.compareKeyed(kb, ka) < 0 {
+			result = append(result, b[j])
+			if j++; j < len(b) {
+				kb = KeyedString{Orig: b[j], Key: k.Key(b[j])}
+			}
+		} else {
+			result = append(result, a[i])
+			if i++; i < len(a) {
+				ka = KeyedString{Orig: a[i], Key: k.Key(a[i])}
+			}
+		}
+	}
+	result = append(result, a[i:]...)
+	return append(result, b[j:]...)
+}
+
+// Dedupe returns the strings in strs
+// without those whose keys equal the key of an earlier one,
+// so that of "The Hobbit" and "Hobbit, The"
+// only the first is kept.
+// The order of the remaining strings is unchanged.
+// It uses the default [Keyer] (see [SetDefault]).
+func Dedupe(strs []string) []string {
+	return Default().Dedupe(strs)
+}
+
+// Dedupe returns the strings in strs
+// without those whose keys equal the key of an earlier one.
+// See [Dedupe].
+func (k *Keyer) Dedupe(strs []string) []string {
+	var (
+		result = make([]string, 0, len(strs))
+		seen   = make(map[string]bool)
+	)
+	for _, s := range strs {
+		key := k.Key(s)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, s)
+	}
+	return result
+}
+
+// Sorted returns an iterator over the strings in seq in bibliographic order.
+// Strings whose keys are equal keep their order in seq.
+// The strings are read from seq, and their keys computed,
+// when iteration begins.
+// (For more strings than fit in memory, see [SortedWriter].)
+// It uses the default [Keyer] (see [SetDefault]).
+func Sorted(seq iter.Seq[string]) iter.Seq[string] {
+	return Default().Sorted(seq)
+}
+
+// Sorted returns an iterator over the strings in seq in order by their keys.
+// See [Sorted].
+func (k *Keyer) Sorted(seq iter.Seq[string]) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		var keyed []KeyedString
+		for s := range seq {
+			keyed = append(keyed, KeyedString{Orig: s, Key: k.Key(s)})
+		}
+		k.sortKeyed(keyed)
+		for _, e := range keyed {
+			if !yield(e.Orig) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns the keys of strs,
+// in the same order,
+// e.g. for storing in a database column to sort by.
+// It uses the default [Keyer] (see [SetDefault]).
+func Keys(strs []string) []string {
+	return Default().Keys(strs)
+}
+
+// Keys returns the keys of strs, in the same order.
+func (k *Keyer) Keys(strs []string) []string {
+	return slices.Map(strs, k.Key)
+}
+
+// KeyedString is a string together with its key.
+type KeyedString struct {
+	Orig, Key string
+}
+
+// MakeKeyed pairs each of strs with its key.
+// It uses the default [Keyer] (see [SetDefault]).
+func MakeKeyed(strs []string) []KeyedString {
+	return Default().MakeKeyed(strs)
+}
+
+// MakeKeyed pairs each of strs with its key.
+func (k *Keyer) MakeKeyed(strs []string) []KeyedString {
+	return slices.Map(strs, func(s string) KeyedString { return KeyedString{Orig: s, Key: k.Key(s)} })
+}
+
+// SortKeyed sorts ks by their keys.
+// Elements whose keys are equal keep their original order.
+func SortKeyed(ks []KeyedString) {
+	sort.SliceStable(ks, func(i, j int) bool { return ks[i].Key < ks[j].Key })
+}
+
+// IsSortedKeyed tells whether ks is in order by their keys.
+func IsSortedKeyed(ks []KeyedString) bool {
+	return sort.SliceIsSorted(ks, func(i, j int) bool { return ks[i].Key < ks[j].Key })
+}
+
+// Origs returns the original strings of ks.
+func Origs(ks []KeyedString) []string {
+	return slices.Map(ks, func(k KeyedString) string { return k.Orig })
+}
+
+// Interface returns a [sort.Interface] for sorting strs bibliographically
+// with e.g. [sort.Stable].
+// It has no Push or Pop,
+// so it can't be used with [container/heap];
+// for a priority queue, see [Heap].
+// It computes the keys of strs once, up front,
+// and swaps them along with the strings,
+// so strs must not be changed except through it.
+// It uses the default [Keyer] (see [SetDefault]).
+func Interface(strs []string) sort.Interface {
+	return Default().Interface(strs)
+}
+
+// Interface returns a [sort.Interface] for sorting strs by their keys.
+// See [Interface].
+func (k *Keyer) Interface(strs []string) sort.Interface {
+	return keyedSorter[string]{k: k, items: strs, keyed: k.MakeKeyed(strs)}
+}
+
+// Cmp returns a comparison function for strings
+// for use with e.g. [slices.SortFunc] and [slices.BinarySearchFunc] in the standard library.
+// The function returns -1, 0, or 1 like [Compare],
+// and is consistent with [Less]:
+// cmp(a, b) < 0 exactly when Less(a, b).
+// It uses the Keyer that is the default (see [SetDefault])
+// at the time Cmp is called.
+func Cmp() func(a, b string) int {
+	return Default().Cmp()
+}
+
+// Cmp returns [Keyer.Compare] as a function value.
+// See [Cmp].
+func (k *Keyer) Cmp() func(a, b string) int {
+	return k.Compare
+}
+
+// Min returns the bibliographically first of strs,
+// e.g. for an "Aardvark … Zygote" range heading,
+// in one pass and without sorting strs.
+// Of strings whose keys are equal, the earliest in strs is chosen,
+// as [SortStable] would place it first.
+// It returns "" if strs is empty.
+// It uses the default [Keyer] (see [SetDefault]).
+func Min(strs []string) string {
+	return Default().Min(strs)
+}
+
+// Min returns the first of strs in order by their keys.
+// See [Min].
+func (k *Keyer) Min(strs []string) string {
+	return k.extreme(strs, func(c int) bool { return c < 0 })
+}
+
+// Max returns the bibliographically last of strs
+// in one pass and without sorting strs.
+// Of strings whose keys are equal, the latest in strs is chosen,
+// as [SortStable] would place it last.
+// It returns "" if strs is empty.
+// It uses the default [Keyer] (see [SetDefault]).
+func Max(strs []string) string {
+	return Default().Max(strs)
+}
+
+// Max returns the last of strs in order by their keys.
+// See [Max].
+func (k *Keyer) Max(strs []string) string {
+	return k.extreme(strs, func(c int) bool { return c >= 0 })
+}
+
+// extreme returns the element of strs that replaces each earlier choice
+// for which better returns true,
+// given the comparison of the element with the choice.
+func (k *Keyer) extreme(strs []string, better func(int) bool) string {
+	var best KeyedString
+	for i, s := range strs {
+		cur := KeyedString{Orig: s, Key: k.Key(s)}
+		if i == 0 || better(k.compareKeyed(cur, best)) {
+			best = cur
+		}
+	}
+	return best.Orig
+}
+
+// TopK returns the first n of strs in bibliographic order,
+// or all of them if there are fewer than n,
+// as [SortStable] would order them.
+// It takes time proportional to len(strs)·log(n)
+// and does not change strs,
+// so a page of a large catalog can be produced without sorting all of it.
+// It uses the default [Keyer] (see [SetDefault]).
+func TopK(strs []string, n int) []string {
+	return Default().TopK(strs, n)
+}
+
+// TopK returns the first n of strs in order by their keys.
+// See [TopK].
+func (k *Keyer) TopK(strs []string, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	// h is a max-heap of the best n so far,
+	// with the worst at the top.
+	h := topKHeap{k: k}
+	for i, s := range strs {
+		e := indexedKeyed{KeyedString: KeyedString{Orig: s, Key: k.Key(s)}, index: i}
+		if len(h.entries) < n {
+			heap.Push(&h, e)
+			continue
+		}
+		if h.before(e, h.entries[0]) {
+			h.entries[0] = e
+			heap.Fix(&h, 0)
+		}
+	}
+
+	result := make([]string, len(h.entries))
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(&h).(indexedKeyed).Orig
+	}
+	return result
+}
+
+// indexedKeyed is a keyed string and its position in the order of input,
+// for ordering strings whose keys are equal.
+type indexedKeyed struct {
+	KeyedString
+	index int
+}
+
+type topKHeap struct {
+	k       *Keyer
+	entries []indexedKeyed
+}
+
+// before tells whether a sorts before b,
+// using their positions to break ties.
+func (h topKHeap) before(a, b indexedKeyed) bool {
+	if c := h.k.compareKeyed(a.KeyedString, b.KeyedString); c != 0 {
+		return c < 0
+	}
+	return a.index < b.index
+}
+
+func (h topKHeap) Len() int           { return len(h.entries) }
+func (h topKHeap) Less(i, j int) bool { return h.before(h.entries[j], h.entries[i]) }
+func (h topKHeap) Swap(i, j int)      { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+
+func (h *topKHeap) Push(x any) { h.entries = append(h.entries, x.(indexedKeyed)) }
+
+func (h *topKHeap) Pop() any {
+	e := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return e
+}
+
+// Insert inserts s into sorted,
+// which must be in bibliographic order (as by [SortStable]),
+// keeping it in order,
+// and returns the updated slice.
+// If sorted already has strings whose keys equal the key of s,
+// s goes after them,
+// so a list built with Insert is in the order SortStable would give.
+// It computes the keys of only O(log n) strings.
+// It uses the default [Keyer] (see [SetDefault]).
+func Insert(sorted []string, s string) []string {
+	return Default().Insert(sorted, s)
+}
+
+// Insert inserts s into sorted,
+// which must be in order by their keys.
+// See [Insert].
+func (k *Keyer) Insert(sorted []string, s string) []string {
+	e := KeyedString{Orig: s, Key: k.Key(s)}
+	i := sort.Search(len(sorted), func(i int) bool {
+		return k.compareKeyed(KeyedString{Orig: sorted[i], Key: k.Key(sorted[i])}, e) > 0
+	})
+	return slices.Insert(sorted, i, s)
+}
+
+// SortedKeys returns the keys of m in bibliographic order.
+// Since map iteration order is random,
+// keys whose sort keys are equal (such as "The Heat" and "Heat")
+// are ordered by the tie-breaking function of the default Keyer (see [WithTieBreak]),
+// if there is one,
+// and otherwise by [strings.Compare],
+// so that the result is always the same.
+// It uses the default [Keyer] (see [SetDefault]).
+func SortedKeys[V any](m map[string]V) []string {
+	k := Default()
+	keyed := make([]KeyedString, 0, len(m))
+	for s := range m {
+		keyed = append(keyed, KeyedString{Orig: s, Key: k.Key(s)})
+	}
+	sort.Slice(keyed, func(i, j int) bool {
+		if c := k.compareKeyed(keyed[i], keyed[j]); c != 0 {
+			return c < 0
+		}
+		return keyed[i].Orig < keyed[j].Orig
+	})
+	return Origs(keyed)
+}
@@ -0,0 +1,352 @@
+package bib
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestSortFunc(t *testing.T) {
+	type album struct {
+		artist, title string
+	}
+	albums := []album{
+		{"The Beatles", "Revolver"},
+		{"Pink Floyd", "The Wall"},
+		{"Talking Heads", "77"},
+		{"Radiohead", "OK Computer"},
+	}
+	SortFunc(albums, func(a album) string { return a.title })
+
+	want := []album{
+		{"Radiohead", "OK Computer"},
+		{"The Beatles", "Revolver"},
+		{"Talking Heads", "77"},
+		{"Pink Floyd", "The Wall"},
+	}
+	if !reflect.DeepEqual(albums, want) {
+		t.Errorf("got %v, want %v", albums, want)
+	}
+}
+
+func TestSortStable(t *testing.T) {
+	x := []string{"Heat", "Zulu", "The Heat", "A Heat", "Alpha", "heat"}
+	SortStable(x)
+	want := []string{"Alpha", "Heat", "The Heat", "A Heat", "heat", "Zulu"}
+	if !reflect.DeepEqual(x, want) {
+		t.Errorf("got %q, want %q", x, want)
+	}
+}
+
+func TestSortDesc(t *testing.T) {
+	x := []string{"Heat", "Alpha", "The Heat", "Zulu", "A Heat"}
+	SortDesc(x)
+	want := []string{"Zulu", "Heat", "The Heat", "A Heat", "Alpha"}
+	if !reflect.DeepEqual(x, want) {
+		t.Errorf("got %q, want %q", x, want)
+	}
+}
+
+func TestIsSorted(t *testing.T) {
+	cases := []struct {
+		inp  []string
+		want bool
+	}{{
+		inp:  nil,
+		want: true,
+	}, {
+		inp:  []string{"The Apple", "Banana", "A Cherry"},
+		want: true,
+	}, {
+		inp:  []string{"The Heat", "Heat", "heat"},
+		want: true,
+	}, {
+		inp:  []string{"Banana", "The Apple"},
+		want: false,
+	}, {
+		inp:  []string{"1984", "Animal Farm"},
+		want: false,
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got := IsSorted(tc.inp); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	k := NewKeyer(WithNumberMode(NumbersByValue))
+	if !k.IsSorted([]string{"1984", "Animal Farm"}) {
+		t.Error("numbers by value: got false, want true")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	strs := []string{"The Apple", "Banana", "A Cherry", "Heat", "The Heat", "Zulu"}
+
+	cases := []struct {
+		target string
+		want   int
+		found  bool
+	}{{
+		target: "Apple",
+		want:   0,
+		found:  true,
+	}, {
+		target: "the heat",
+		want:   3,
+		found:  true,
+	}, {
+		target: "Blueberry",
+		want:   2,
+		found:  false,
+	}, {
+		target: "Aardvark",
+		want:   0,
+		found:  false,
+	}, {
+		target: "Zzz",
+		want:   6,
+		found:  false,
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got, found := Search(strs, tc.target)
+			if got != tc.want || found != tc.found {
+				t.Errorf("got %d, %v; want %d, %v", got, found, tc.want, tc.found)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	cases := []struct {
+		a, b, want []string
+	}{{
+		a:    []string{"The Apple", "A Cherry", "Heat"},
+		b:    []string{"Banana", "The Heat", "Zulu"},
+		want: []string{"The Apple", "Banana", "A Cherry", "Heat", "The Heat", "Zulu"},
+	}, {
+		a:    nil,
+		b:    []string{"Banana"},
+		want: []string{"Banana"},
+	}, {
+		a:    []string{"Banana"},
+		b:    nil,
+		want: []string{"Banana"},
+	}, {
+		a:    nil,
+		b:    nil,
+		want: []string{},
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := Merge(tc.a, tc.b)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	inp := []string{"The Hobbit", "Zulu", "Hobbit, The", "hobbit", "A Hobbit", "Hobbits", "ZULU!"}
+	got := Dedupe(inp)
+	want := []string{"The Hobbit", "Zulu", "Hobbits"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSorted(t *testing.T) {
+	inp := []string{"Zulu", "The Heat", "Heat", "An Apple"}
+	var got []string
+	for s := range Sorted(slices.Values(inp)) {
+		got = append(got, s)
+	}
+	want := []string{"An Apple", "The Heat", "Heat", "Zulu"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// Stopping early.
+	got = nil
+	for s := range Sorted(slices.Values(inp)) {
+		got = append(got, s)
+		if len(got) == 2 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(got, want[:2]) {
+		t.Errorf("got %q, want %q", got, want[:2])
+	}
+}
+
+func TestKeys(t *testing.T) {
+	got := Keys([]string{"The Hobbit", "", "42nd Street"})
+	want := []string{"hobbit", "", "forty-second street"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := Keys(nil); len(got) != 0 {
+		t.Errorf("got %q for no strings, want none", got)
+	}
+}
+
+func TestKeyed(t *testing.T) {
+	ks := MakeKeyed([]string{"Zulu", "The Heat", "Heat", "An Apple"})
+	if IsSortedKeyed(ks) {
+		t.Error("unsorted input reported as sorted")
+	}
+	SortKeyed(ks)
+	if !IsSortedKeyed(ks) {
+		t.Error("sorted output reported as unsorted")
+	}
+	want := []KeyedString{{"An Apple", "apple"}, {"The Heat", "heat"}, {"Heat", "heat"}, {"Zulu", "zulu"}}
+	if !reflect.DeepEqual(ks, want) {
+		t.Errorf("got %q, want %q", ks, want)
+	}
+	if got, want := Origs(ks), []string{"An Apple", "The Heat", "Heat", "Zulu"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestInterface(t *testing.T) {
+	x := []string{"Zulu", "The Heat", "Heat", "An Apple"}
+	s := Interface(x)
+	if sort.IsSorted(s) {
+		t.Error("unsorted input reported as sorted")
+	}
+	sort.Stable(s)
+	if !sort.IsSorted(s) {
+		t.Error("sorted output reported as unsorted")
+	}
+	want := []string{"An Apple", "The Heat", "Heat", "Zulu"}
+	if !reflect.DeepEqual(x, want) {
+		t.Errorf("got %q, want %q", x, want)
+	}
+}
+
+func TestCmp(t *testing.T) {
+	x := []string{"Zulu", "The Heat", "An Apple", "42nd Street"}
+	cmp := Cmp()
+	slices.SortFunc(x, cmp)
+	want := []string{"An Apple", "42nd Street", "The Heat", "Zulu"}
+	if !reflect.DeepEqual(x, want) {
+		t.Errorf("got %q, want %q", x, want)
+	}
+
+	i, found := slices.BinarySearchFunc(x, "Heat", cmp)
+	if i != 2 || !found {
+		t.Errorf("got %d, %v from BinarySearchFunc; want 2, true", i, found)
+	}
+
+	for _, a := range x {
+		for _, b := range x {
+			if (cmp(a, b) < 0) != Less(a, b) {
+				t.Errorf("Cmp and Less disagree on %q and %q", a, b)
+			}
+		}
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	cases := []struct {
+		inp              []string
+		wantMin, wantMax string
+	}{{
+		inp: nil,
+	}, {
+		inp:     []string{"Solo"},
+		wantMin: "Solo",
+		wantMax: "Solo",
+	}, {
+		inp:     []string{"Zygote", "The Aardvark", "Mongoose"},
+		wantMin: "The Aardvark",
+		wantMax: "Zygote",
+	}, {
+		inp:     []string{"The Heat", "Alpha", "Heat", "A Alpha"},
+		wantMin: "Alpha",
+		wantMax: "Heat",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			if got := Min(tc.inp); got != tc.wantMin {
+				t.Errorf("got min %q, want %q", got, tc.wantMin)
+			}
+			if got := Max(tc.inp); got != tc.wantMax {
+				t.Errorf("got max %q, want %q", got, tc.wantMax)
+			}
+		})
+	}
+}
+
+func TestTopK(t *testing.T) {
+	inp := []string{"Zulu", "The Heat", "Echo", "Alpha", "Heat", "Mike", "A Bravo"}
+
+	cases := []struct {
+		n    int
+		want []string
+	}{{
+		n:    0,
+		want: nil,
+	}, {
+		n:    1,
+		want: []string{"Alpha"},
+	}, {
+		n:    4,
+		want: []string{"Alpha", "A Bravo", "Echo", "The Heat"},
+	}, {
+		n:    5,
+		want: []string{"Alpha", "A Bravo", "Echo", "The Heat", "Heat"},
+	}, {
+		n:    100,
+		want: []string{"Alpha", "A Bravo", "Echo", "The Heat", "Heat", "Mike", "Zulu"},
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			orig := slices.Clone(inp)
+			got := TopK(inp, tc.n)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+			if !reflect.DeepEqual(inp, orig) {
+				t.Errorf("input changed to %q", inp)
+			}
+		})
+	}
+}
+
+func TestInsert(t *testing.T) {
+	var got []string
+	for _, s := range []string{"Mike", "The Heat", "Zulu", "Alpha", "Heat", "A Mike", "Echo"} {
+		got = Insert(got, s)
+	}
+	want := []string{"Alpha", "Echo", "The Heat", "Heat", "Mike", "A Mike", "Zulu"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	m := map[string]int{
+		"Zulu":     1,
+		"The Heat": 2,
+		"Alpha":    3,
+		"Heat":     4,
+		"A Heat":   5,
+	}
+	want := []string{"Alpha", "A Heat", "Heat", "The Heat", "Zulu"}
+	for i := 0; i < 10; i++ {
+		if got := SortedKeys(m); !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package bib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// keyVersion identifies the algorithm implemented by Key.
+// It must be incremented whenever a change could alter Key's output for some input,
+// so that stored sort keys can be recognized as stale.
+const keyVersion = 20
+
+// KeyVersion identifies the version of the algorithm implemented by [Key].
+// It changes whenever a new release of this package could produce
+// a different key for some input,
+// so an application that stores keys
+// can store the version alongside them
+// and recompute the keys when it changes
+// (or use [SortKey], which does this).
+// The keys a [Keyer] produces also depend on its options,
+// which the application must keep track of itself.
+func KeyVersion() int {
+	return keyVersion
+}
+
+// SortKey is a bibliographic sort key
+// together with the version of the algorithm that produced it.
+// It can be cached or transmitted as text, JSON, or gob,
+// and checked for staleness when loaded.
+//
+// The serialized form is the version number, a colon, and the key,
+// e.g. "4:gumball rally".
+type SortKey struct {
+	Version int
+	Key     string
+}
+
+// ErrStaleKey is the error produced when decoding a [SortKey]
+// whose version is not the current one.
+// The decoded SortKey is still populated,
+// but its Key should be recomputed from the original string.
+var ErrStaleKey = errors.New("stale sort key")
+
+// MakeSortKey computes the [SortKey] for s.
+func MakeSortKey(s string) SortKey {
+	return SortKey{Version: keyVersion, Key: Key(s)}
+}
+
+// Current tells whether k was produced by the current version of the key algorithm.
+func (k SortKey) Current() bool {
+	return k.Version == keyVersion
+}
+
+// MarshalText implements [encoding.TextMarshaler].
+func (k SortKey) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(k.Version) + ":" + k.Key), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler].
+// It returns an error wrapping [ErrStaleKey]
+// if the decoded key is not [SortKey.Current].
+func (k *SortKey) UnmarshalText(text []byte) error {
+	v, key, ok := strings.Cut(string(text), ":")
+	if !ok {
+		return fmt.Errorf("malformed sort key %q: no version", text)
+	}
+	version, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("malformed sort key %q: bad version: %w", text, err)
+	}
+
+	k.Version, k.Key = version, key
+	if !k.Current() {
+		return fmt.Errorf("sort key version %d, current version %d: %w", version, keyVersion, ErrStaleKey)
+	}
+	return nil
+}
+
+// MarshalJSON implements [json.Marshaler].
+// The key is encoded as a JSON string in its text form.
+func (k SortKey) MarshalJSON() ([]byte, error) {
+	text, err := k.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+func (k *SortKey) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("decoding sort key: %w", err)
+	}
+	return k.UnmarshalText([]byte(s))
+}
+
+// GobEncode implements [encoding/gob.GobEncoder].
+func (k SortKey) GobEncode() ([]byte, error) {
+	return k.MarshalText()
+}
+
+// GobDecode implements [encoding/gob.GobDecoder].
+func (k *SortKey) GobDecode(data []byte) error {
+	return k.UnmarshalText(data)
+}
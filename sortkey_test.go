@@ -0,0 +1,64 @@
+package bib
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSortKeyJSON(t *testing.T) {
+	k := MakeSortKey("The Gumball Rally")
+
+	j, err := json.Marshal(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := fmt.Sprintf(`"%d:gumball rally"`, keyVersion); string(j) != want {
+		t.Errorf("got %s, want %s", j, want)
+	}
+
+	var got SortKey
+	if err := json.Unmarshal(j, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != k {
+		t.Errorf("got %+v, want %+v", got, k)
+	}
+}
+
+func TestSortKeyGob(t *testing.T) {
+	k := MakeSortKey("42nd Street")
+
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(k); err != nil {
+		t.Fatal(err)
+	}
+	var got SortKey
+	if err := gob.NewDecoder(buf).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got != k {
+		t.Errorf("got %+v, want %+v", got, k)
+	}
+}
+
+func TestSortKeyValidation(t *testing.T) {
+	var k SortKey
+
+	err := k.UnmarshalText([]byte("0:gumball rally"))
+	if !errors.Is(err, ErrStaleKey) {
+		t.Errorf("got error %v, want ErrStaleKey", err)
+	}
+	if k.Key != "gumball rally" || k.Current() {
+		t.Errorf("got %+v after decoding a stale key", k)
+	}
+
+	for _, bad := range []string{"gumball rally", "x:gumball rally"} {
+		if err := k.UnmarshalText([]byte(bad)); err == nil || errors.Is(err, ErrStaleKey) {
+			t.Errorf("decoding %q: got error %v, want a format error", bad, err)
+		}
+	}
+}
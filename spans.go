@@ -0,0 +1,56 @@
+package bib
+
+// Span relates a word of a key to the part of the input it came from.
+type Span struct {
+	// KeyStart and KeyEnd are the byte offsets of the word in the key.
+	KeyStart, KeyEnd int
+
+	// Start and End are the byte offsets in the input
+	// of the whitespace-delimited chunk that the word came from,
+	// including any punctuation,
+	// as "Rally," in "The Gumball Rally, Part Two."
+	// Several words may come from the same chunk,
+	// as "nineteen" and "eighty-four" come from "1984."
+	Start, End int
+}
+
+// KeySpans computes the key of s, like [Key],
+// together with where in s each word of the key came from,
+// e.g. for highlighting the words of a title that match a search
+// made against keys.
+// The spans are in order.
+// Input with nothing to file on, such as "!!!", has no spans.
+// It uses the default [Keyer] (see [SetDefault]).
+func KeySpans(s string) (string, []Span) {
+	return Default().KeySpans(s)
+}
+
+// KeySpans computes the key of s
+// together with where in s each word of the key came from.
+// See [KeySpans].
+// With [WithResolver], [WithMarkupStripping], or [WithParallelTitles],
+// the offsets are into the resolved string,
+// with its markup removed,
+// or the chosen parallel title.
+func (k *Keyer) KeySpans(s string) (string, []Span) {
+	var spans []Span
+	key, _ := k.keySpans(s, &spans, nil)
+	return key, spans
+}
+
+// adjustSpans shifts the key offsets in spans by shift bytes
+// and removes or shortens the spans of words past the first n bytes,
+// to account for the key's changes after its words are joined.
+func adjustSpans(spans *[]Span, shift, n int) {
+	result := (*spans)[:0]
+	for _, sp := range *spans {
+		sp.KeyStart += shift
+		sp.KeyEnd += shift
+		if sp.KeyStart >= n {
+			break
+		}
+		sp.KeyEnd = min(sp.KeyEnd, n)
+		result = append(result, sp)
+	}
+	*spans = result
+}
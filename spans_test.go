@@ -0,0 +1,61 @@
+package bib
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestKeySpans(t *testing.T) {
+	cases := []struct {
+		k    *Keyer
+		inp  string
+		want []string // pairs of key words and the input they came from
+	}{{
+		inp:  "The Gumball Rally",
+		want: []string{"gumball", "Gumball", "rally", "Rally"},
+	}, {
+		inp:  "1984, Revisited",
+		want: []string{"nineteen", "1984,", "eighty-four", "1984,", "revisited", "Revisited"},
+	}, {
+		inp:  "Rock & Roll",
+		want: []string{"rock", "Rock", "and", "&", "roll", "Roll"},
+	}, {
+		inp:  "Hobbit, The",
+		want: []string{"hobbit", "Hobbit,"},
+	}, {
+		k:    NewKeyer(WithLetterByLetter()),
+		inp:  "42nd Street, New York",
+		want: []string{"fortysecond", "42nd", "street", "Street,", "new", "New", "york", "York"},
+	}, {
+		k:    NewKeyer(WithMaxBytes(10)),
+		inp:  "Catching Fire Again",
+		want: []string{"catching", "Catching"},
+	}, {
+		k:    NewKeyer(WithHangul(HangulBlock)),
+		inp:  "한국 영화",
+		want: []string{"한국", "한국", "영화", "영화"},
+	}, {
+		inp: "!!!",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			k := tc.k
+			if k == nil {
+				k = Default()
+			}
+			key, spans := k.KeySpans(tc.inp)
+			if want := k.Key(tc.inp); key != want {
+				t.Errorf("got key %q, want %q", key, want)
+			}
+			var got []string
+			for _, sp := range spans {
+				got = append(got, key[sp.KeyStart:sp.KeyEnd], tc.inp[sp.Start:sp.End])
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package bib
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Report is a summary of the keys of a corpus, produced by [Stats].
+type Report struct {
+	// Entries is the number of entries in the corpus.
+	Entries int
+
+	// Unfiled is the number of entries with nothing to file on,
+	// such as empty strings.
+	// They are not counted in the other fields.
+	Unfiled int
+
+	// DistinctKeys is the number of different keys.
+	DistinctKeys int
+
+	// CollidingKeys is the number of keys shared by more than one entry,
+	// and CollidingEntries is the number of entries having such keys.
+	CollidingKeys, CollidingEntries int
+
+	// LargestCollision is the largest number of entries sharing one key.
+	LargestCollision int
+
+	// Buckets counts entries by the first character of their keys,
+	// e.g. for sizing the pages of an A–Z browse.
+	// Letters count under themselves, in lowercase;
+	// keys beginning with anything else count under "#".
+	Buckets map[string]int
+
+	// ArticlesStripped is the number of entries whose leading article was dropped.
+	ArticlesStripped int
+
+	// NumbersConverted is the number of entries whose leading number was spelled out.
+	NumbersConverted int
+}
+
+// Stats summarizes the keys that [Key] produces for a corpus.
+func Stats(corpus []string) Report {
+	return Default().Stats(corpus)
+}
+
+// Stats summarizes the keys that k produces for a corpus.
+func (k *Keyer) Stats(corpus []string) Report {
+	var (
+		r      = Report{Entries: len(corpus), Buckets: make(map[string]int)}
+		counts = make(map[string]int)
+	)
+	for _, s := range corpus {
+		key, info := k.key(s)
+		if info.unfiled {
+			r.Unfiled++
+			continue
+		}
+		counts[key]++
+		if info.articleStripped {
+			r.ArticlesStripped++
+		}
+		if info.numberConverted {
+			r.NumbersConverted++
+		}
+
+		bucket := "#"
+		if first, _ := utf8.DecodeRuneInString(key); unicode.IsLetter(first) {
+			bucket = string(first)
+		}
+		r.Buckets[bucket]++
+	}
+
+	r.DistinctKeys = len(counts)
+	for _, n := range counts {
+		if n > 1 {
+			r.CollidingKeys++
+			r.CollidingEntries += n
+		}
+		r.LargestCollision = max(r.LargestCollision, n)
+	}
+	return r
+}
@@ -0,0 +1,37 @@
+package bib
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	corpus := []string{
+		"The Gumball Rally",
+		"Gumball Rally",
+		"gumball rally!",
+		"A Tale of Two Cities",
+		"Tale of Two Cities",
+		"42nd Street",
+		"9 to 5",
+		"Emma",
+		"",
+		"---",
+	}
+
+	got := Stats(corpus)
+	want := Report{
+		Entries:          10,
+		Unfiled:          2,
+		DistinctKeys:     5,
+		CollidingKeys:    2,
+		CollidingEntries: 5,
+		LargestCollision: 3,
+		Buckets:          map[string]int{"g": 3, "t": 2, "f": 1, "n": 1, "e": 1},
+		ArticlesStripped: 2,
+		NumbersConverted: 2,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
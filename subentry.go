@@ -0,0 +1,50 @@
+package bib
+
+// WithSubentryFiling makes a [Keyer] ignore leading articles,
+// prepositions,
+// and conjunctions,
+// as the Chicago Manual of Style prescribes for index subentries,
+// so that "of Indian treaties" files under "Indian."
+// A word is ignored only if others follow it.
+func WithSubentryFiling() Option {
+	return func(c *config) {
+		c.subentry = true
+	}
+}
+
+var subentryWords = map[string]bool{
+	"a":       true,
+	"about":   true,
+	"after":   true,
+	"against": true,
+	"among":   true,
+	"an":      true,
+	"and":     true,
+	"as":      true,
+	"at":      true,
+	"before":  true,
+	"between": true,
+	"but":     true,
+	"by":      true,
+	"during":  true,
+	"for":     true,
+	"from":    true,
+	"in":      true,
+	"into":    true,
+	"nor":     true,
+	"of":      true,
+	"on":      true,
+	"or":      true,
+	"over":    true,
+	"than":    true,
+	"the":     true,
+	"to":      true,
+	"under":   true,
+	"upon":    true,
+	"versus":  true,
+	"via":     true,
+	"vs":      true,
+	"with":    true,
+	"within":  true,
+	"without": true,
+}
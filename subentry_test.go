@@ -0,0 +1,40 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSubentryFiling(t *testing.T) {
+	cases := []struct {
+		inp, want string
+	}{{
+		inp:  "of Indian treaties",
+		want: "indian treaties",
+	}, {
+		inp:  "in the courts",
+		want: "courts",
+	}, {
+		inp:  "and",
+		want: "and",
+	}, {
+		inp:  "as governor",
+		want: "governor",
+	}, {
+		inp:  "elections",
+		want: "elections",
+	}, {
+		inp:  "U.S. relations with",
+		want: "us relations with",
+	}}
+
+	k := NewKeyer(WithSubentryFiling())
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := k.Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
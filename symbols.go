@@ -0,0 +1,143 @@
+package bib
+
+import (
+	"strings"
+	"unicode"
+)
+
+// defaultSymbolBucket is the default for [WithSymbolBucket].
+const defaultSymbolBucket = "#"
+
+// WithSymbolBucket sets the bucket under which a [Keyer] files input
+// consisting only of symbols and punctuation,
+// like "!!!" or "+/-",
+// which would otherwise normalize to nothing.
+// The key for such input is the bucket, a space, and the input's symbols
+// (without whitespace),
+// so that such entries sort together, in a definite order,
+// and apart from everything else.
+// The default bucket is "#,"
+// which sorts before all keys made from letters and digits,
+// unless [WithPlacement] says otherwise.
+//
+// See also [WithSpelledSymbols].
+func WithSymbolBucket(bucket string) Option {
+	return func(c *config) {
+		c.symbolBucket = &bucket
+	}
+}
+
+// WithSpelledSymbols makes a [Keyer] file input consisting only of symbols and punctuation
+// under the spelled-out names of the symbols,
+// so that "!!!" files as "exclamation exclamation exclamation"
+// and "+/-" as "plus slash minus."
+// Symbols without a name here are kept as they are.
+// This takes precedence over [WithSymbolBucket].
+func WithSpelledSymbols() Option {
+	return func(c *config) {
+		c.spellSymbols = true
+	}
+}
+
+// WithSpelledPercent makes a [Keyer] treat "%" in its input as the word "percent,"
+// as it does "&" and "and,"
+// so that "50% Off" files as "fifty percent off"
+// rather than "fifty off."
+func WithSpelledPercent() Option {
+	return func(c *config) {
+		c.spellPercent = true
+	}
+}
+
+// symbolKey produces the key for s,
+// which has no letters or digits but is not all whitespace.
+func (k *Keyer) symbolKey(s string) string {
+	syms := strings.Join(strings.Fields(s), "")
+
+	if k.cfg.spellSymbols {
+		var words []string
+		for _, r := range syms {
+			if unicode.IsMark(r) || unicode.Is(unicode.Variation_Selector, r) {
+				continue
+			}
+			if name, ok := symbolNames[r]; ok {
+				words = append(words, name)
+			} else {
+				words = append(words, string(r))
+			}
+		}
+		return strings.Join(words, " ")
+	}
+
+	bucket := defaultSymbolBucket
+	if k.cfg.placement == PlaceLast {
+		bucket = lastKey
+	}
+	if k.cfg.symbolBucket != nil {
+		bucket = *k.cfg.symbolBucket
+	}
+	return bucket + " " + syms
+}
+
+var symbolNames = map[rune]string{
+	'!':  "exclamation",
+	'"':  "quote",
+	'#':  "number",
+	'$':  "dollar",
+	'%':  "percent",
+	'&':  "and",
+	'\'': "apostrophe",
+	'(':  "open parenthesis",
+	')':  "close parenthesis",
+	'*':  "asterisk",
+	'+':  "plus",
+	',':  "comma",
+	'-':  "minus",
+	'.':  "period",
+	'/':  "slash",
+	':':  "colon",
+	';':  "semicolon",
+	'<':  "less than",
+	'=':  "equals",
+	'>':  "greater than",
+	'?':  "question",
+	'@':  "at",
+	'[':  "open bracket",
+	'\\': "backslash",
+	']':  "close bracket",
+	'^':  "caret",
+	'_':  "underscore",
+	'`':  "backquote",
+	'{':  "open brace",
+	'|':  "bar",
+	'}':  "close brace",
+	'~':  "tilde",
+	'¡':  "inverted exclamation",
+	'¢':  "cent",
+	'£':  "pound",
+	'§':  "section",
+	'©':  "copyright",
+	'®':  "registered",
+	'°':  "degree",
+	'±':  "plus minus",
+	'¶':  "pilcrow",
+	'·':  "middle dot",
+	'¿':  "inverted question",
+	'×':  "times",
+	'÷':  "divided by",
+	'–':  "dash",
+	'—':  "dash",
+	'‘':  "quote",
+	'’':  "apostrophe",
+	'“':  "quote",
+	'”':  "quote",
+	'•':  "bullet",
+	'…':  "ellipsis",
+	'€':  "euro",
+	'™':  "trademark",
+	'←':  "left arrow",
+	'→':  "right arrow",
+	'♥':  "heart",
+	'★':  "star",
+	'☆':  "star",
+}
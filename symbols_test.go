@@ -0,0 +1,78 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSymbols(t *testing.T) {
+	cases := []struct {
+		opts []Option
+		inp  string
+		want string
+	}{{
+		inp:  "!!!",
+		want: "# !!!",
+	}, {
+		inp:  "+/-",
+		want: "# +/-",
+	}, {
+		inp:  "? ? ?",
+		want: "# ???",
+	}, {
+		opts: []Option{WithSymbolBucket("~")},
+		inp:  "???",
+		want: "~ ???",
+	}, {
+		opts: []Option{WithSpelledSymbols()},
+		inp:  "!!!",
+		want: "exclamation exclamation exclamation",
+	}, {
+		opts: []Option{WithSpelledSymbols()},
+		inp:  "+/-",
+		want: "plus slash minus",
+	}, {
+		opts: []Option{WithSpelledSymbols()},
+		inp:  "…",
+		want: "ellipsis",
+	}, {
+		opts: []Option{WithSpelledSymbols()},
+		inp:  "The Who",
+		want: "who",
+	}, {
+		inp:  "50% Off",
+		want: "fifty off",
+	}, {
+		opts: []Option{WithSpelledPercent()},
+		inp:  "50% Off",
+		want: "fifty percent off",
+	}, {
+		opts: []Option{WithSpelledPercent()},
+		inp:  "The 99%",
+		want: "ninety-nine percent",
+	}, {
+		opts: []Option{WithSpelledPercent(), WithNumberMode(NumbersByValue)},
+		inp:  "50% Off",
+		want: "0250 percent off",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := NewKeyer(tc.opts...).Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSymbolsSortFirst(t *testing.T) {
+	inp := []string{"Zebra", "???", "1984", "!!!", "Aardvark"}
+	Sort(inp)
+	want := []string{"!!!", "???", "Aardvark", "1984", "Zebra"}
+	for i := range want {
+		if inp[i] != want[i] {
+			t.Fatalf("got %v, want %v", inp, want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package bib
+
+import (
+	"sort"
+	"strings"
+)
+
+// WithTieBreak makes a [Keyer] order strings whose keys are equal,
+// such as "The Heat" and "Heat,"
+// by the given comparison function
+// (which returns a negative number, zero, or a positive number
+// according to whether a sorts before, the same as, or after b).
+// With a function like [strings.Compare]
+// that distinguishes all strings,
+// the results of sorting are fully determined by the input,
+// regardless of its order.
+//
+// This affects the Keyer's methods for comparing, sorting, and merging strings,
+// but not [Keyer.Equal] or [Keyer.Search],
+// which depend only on keys.
+func WithTieBreak(cmp func(a, b string) int) Option {
+	return func(c *config) {
+		c.tieBreak = cmp
+	}
+}
+
+// compareKeyed compares a and b by their keys
+// and then by k's tie-breaking function, if any.
+func (k *Keyer) compareKeyed(a, b KeyedString) int {
+	if c := strings.Compare(a.Key, b.Key); c != 0 || k.cfg.tieBreak == nil {
+		return c
+	}
+	return k.cfg.tieBreak(a.Orig, b.Orig)
+}
+
+// keyedSorter sorts items by their keyed strings,
+// keeping the two slices in step.
+type keyedSorter[T any] struct {
+	k     *Keyer
+	items []T
+	keyed []KeyedString
+}
+
+func (s keyedSorter[T]) Len() int { return len(s.items) }
+
+func (s keyedSorter[T]) Less(i, j int) bool { return s.k.compareKeyed(s.keyed[i], s.keyed[j]) < 0 }
+
+func (s keyedSorter[T]) Swap(i, j int) {
+	s.items[i], s.items[j] = s.items[j], s.items[i]
+	s.keyed[i], s.keyed[j] = s.keyed[j], s.keyed[i]
+}
+
+// sortKeyed sorts ks stably by their keys and k's tie-breaking function.
+func (k *Keyer) sortKeyed(ks []KeyedString) {
+	sort.SliceStable(ks, func(i, j int) bool { return k.compareKeyed(ks[i], ks[j]) < 0 })
+}
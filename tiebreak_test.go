@@ -0,0 +1,76 @@
+package bib
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTieBreak(t *testing.T) {
+	k := NewKeyer(WithTieBreak(strings.Compare))
+	want := []string{"Apple", "Heat", "The Heat", "heat", "Zulu"}
+
+	inputs := [][]string{
+		{"Zulu", "heat", "The Heat", "Apple", "Heat"},
+		{"The Heat", "Heat", "heat", "Zulu", "Apple"},
+		{"heat", "Apple", "Zulu", "Heat", "The Heat"},
+	}
+	for _, inp := range inputs {
+		for _, sortFn := range []func([]string){k.Sort, k.SortStable} {
+			x := append([]string(nil), inp...)
+			sortFn(x)
+			if !reflect.DeepEqual(x, want) {
+				t.Errorf("sorting %q: got %q, want %q", inp, x, want)
+			}
+		}
+
+		var got []string
+		for s := range k.Sorted(func(yield func(string) bool) {
+			for _, s := range inp {
+				if !yield(s) {
+					return
+				}
+			}
+		}) {
+			got = append(got, s)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Sorted %q: got %q, want %q", inp, got, want)
+		}
+
+		buf := new(bytes.Buffer)
+		sw := NewSortedWriter(buf, WriterOptions{Keyer: k, MaxMemory: 10})
+		for _, s := range inp {
+			sw.Add(s)
+		}
+		if err := sw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := buf.String(), strings.Join(want, "\n")+"\n"; got != want {
+			t.Errorf("SortedWriter %q: got %q, want %q", inp, got, want)
+		}
+	}
+
+	if got := k.Compare("The Heat", "Heat"); got != 1 {
+		t.Errorf("got %d comparing The Heat with Heat, want 1", got)
+	}
+	if !k.Less("Heat", "The Heat") {
+		t.Error("Heat is not less than The Heat")
+	}
+	if !k.Equal("Heat", "The Heat") {
+		t.Error("Heat is not equal to The Heat")
+	}
+	if k.IsSorted([]string{"The Heat", "Heat"}) {
+		t.Error("The Heat, Heat reported as sorted")
+	}
+	if got, want := k.Merge([]string{"The Heat"}, []string{"Heat"}), []string{"Heat", "The Heat"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q from Merge, want %q", got, want)
+	}
+
+	x := []string{"Heat", "Zulu", "The Heat"}
+	k.SortDesc(x)
+	if want := []string{"Zulu", "The Heat", "Heat"}; !reflect.DeepEqual(x, want) {
+		t.Errorf("got %q from SortDesc, want %q", x, want)
+	}
+}
@@ -0,0 +1,28 @@
+package bib
+
+import "regexp"
+
+// TrackKey converts the title of a music track to a bibliographic sort key.
+// It is like [Key],
+// but first removes any leading track number (see [TrimTrackNumber]),
+// so that e.g. "03 - The Boxer" files as "boxer".
+func TrackKey(title string) string {
+	return Key(TrimTrackNumber(title))
+}
+
+// TrimTrackNumber removes a leading track number from s.
+// A track number is recognized only when it is unambiguous:
+// a number followed by a separator ("3. ", "03 - ", "3) "),
+// a disc-track pair ("1-03 "),
+// or a zero-padded number ("03 ").
+// A title that merely begins with a number,
+// such as "99 Luftballons",
+// is left alone.
+func TrimTrackNumber(s string) string {
+	if loc := trackNumRegex.FindStringIndex(s); loc != nil && loc[1] < len(s) {
+		return s[loc[1]:]
+	}
+	return s
+}
+
+var trackNumRegex = regexp.MustCompile(`^\s*(?:\d{1,2}[-.]\d{1,3}\s+(?:[-.]\s+)?|\d{1,3}\s*[-.)]\s+|0\d{1,2}\s+)`)
@@ -0,0 +1,42 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTrackKey(t *testing.T) {
+	cases := []struct {
+		inp, want string
+	}{{
+		inp:  "03 - The Boxer",
+		want: "boxer",
+	}, {
+		inp:  "3. The Boxer",
+		want: "boxer",
+	}, {
+		inp:  "1-03 The Boxer",
+		want: "boxer",
+	}, {
+		inp:  "03 The Boxer",
+		want: "boxer",
+	}, {
+		inp:  "99 Luftballons",
+		want: "ninety-nine luftballons",
+	}, {
+		inp:  "9 to 5",
+		want: "nine to 5",
+	}, {
+		inp:  "1999",
+		want: "nineteen ninety-nine",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got := TrackKey(tc.inp)
+			if got != tc.want {
+				t.Errorf(`input "%s", got "%s", want "%s"`, tc.inp, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package bib
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// WithVersionNumbers makes a [Keyer] recognize dotted version numbers,
+// such as "3.1" and "2.0.1,"
+// and spell out their parts as whole numbers:
+// "Windows 3.1" files as "windows three point one,"
+// "Web 2.0" as "web two point zero,"
+// and "Mac OS X 10.15" as "mac os x ten point fifteen."
+// This applies anywhere in the input
+// except to a leading number with a single dot,
+// like "3.14,"
+// which is still a decimal number (see [NumberMode]),
+// and to numbers whose dots group digits by thousands
+// (see [WithDecimalComma]),
+// like "1.000.000,"
+// which is still one million.
+// Without this option,
+// "Windows 3.1" runs together as "windows 31."
+func WithVersionNumbers() Option {
+	return func(c *config) {
+		c.versions = true
+	}
+}
+
+var versionRegex = regexp.MustCompile(`^["'(\[]*v?(\d+(?:\.\d+)+)["')\],;:!?.]*$`)
+
+// version tells whether the lowercase chunk is a version number
+// and if so returns it in words.
+func (k *Keyer) version(chunk string) ([]string, bool) {
+	m := versionRegex.FindStringSubmatch(chunk)
+	if m == nil || k.thousands(m[1]) {
+		return nil, false
+	}
+	var words []string
+	for i, num := range strings.Split(m[1], ".") {
+		n, err := strconv.ParseInt(num, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		if i > 0 {
+			words = append(words, "point")
+		}
+		words = append(words, k.spellInt(n, false, false)...)
+	}
+	return words, true
+}
+
+// thousands tells whether the dotted number v groups its digits by thousands:
+// it has groups of exactly three digits after the first,
+// and either more than one dot or [WithDecimalComma].
+func (k *Keyer) thousands(v string) bool {
+	if !groupedIntCommaRegex.MatchString(v) {
+		return false
+	}
+	return k.cfg.decimalComma || strings.Count(v, ".") > 1
+}
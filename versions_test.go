@@ -0,0 +1,62 @@
+package bib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestVersionNumbers(t *testing.T) {
+	cases := []struct {
+		opts      []Option
+		inp, want string
+	}{{
+		inp:  "Windows 3.1",
+		want: "windows three point one",
+	}, {
+		inp:  "Web 2.0",
+		want: "web two point zero",
+	}, {
+		inp:  "Mac OS X 10.15",
+		want: "mac os x ten point fifteen",
+	}, {
+		inp:  "Perl v5.36.0 (2022)",
+		want: "perl five point thirty-six point zero 2022",
+	}, {
+		inp:  "3.14 Reasons",
+		want: "three point one four reasons",
+	}, {
+		inp:  "2.0.1 Release Notes",
+		want: "two point zero point one release notes",
+	}, {
+		inp:  "Windows 95",
+		want: "windows 95",
+	}, {
+		inp:  "1.000.000 Ways to Die",
+		want: "one million ways to die",
+	}, {
+		inp:  "1.000 Nights",
+		want: "one point zero zero zero nights",
+	}, {
+		opts: []Option{WithDecimalComma()},
+		inp:  "1.000 Nights",
+		want: "one thousand nights",
+	}, {
+		opts: []Option{WithDecimalComma()},
+		inp:  "Windows 3.1",
+		want: "windows three point one",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			k := NewKeyer(append([]Option{WithVersionNumbers()}, tc.opts...)...)
+			got := k.Key(tc.inp)
+			if got != tc.want {
+				t.Errorf(`got "%s", want "%s"`, got, tc.want)
+			}
+		})
+	}
+
+	if got, want := Key("Windows 3.1"), "windows 31"; got != want {
+		t.Errorf(`without WithVersionNumbers, got "%s", want "%s"`, got, want)
+	}
+}
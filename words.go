@@ -0,0 +1,131 @@
+package bib
+
+import (
+	"strings"
+)
+
+// WordsToInt parses a number spelled out in English words,
+// such as "forty-two," "forty-second," "one thousand nine hundred seventeen,"
+// or, as a year, "nineteen seventeen" or "nineteen oh seven,"
+// and reports whether it is an ordinal.
+// It accepts the spellings that [Key] produces,
+// including those of [NumberingIndian] and [WithOhYears],
+// and also "and" between words, as in "one hundred and five."
+// Case and hyphens are ignored.
+// It returns false if s is not a number in words.
+func WordsToInt(s string) (n int64, ordinal bool, ok bool) {
+	words := strings.Fields(strings.ToLower(strings.ReplaceAll(s, "-", " ")))
+	if len(words) == 0 {
+		return 0, false, false
+	}
+
+	var (
+		total, cur int64
+		seen       bool  // whether cur has any words in it
+		big        int64 // the largest scale applied to total
+		oh         bool  // whether "oh" must be followed by a digit, as in "nineteen oh seven"
+	)
+	for i, w := range words {
+		if w == "and" && i > 0 && i < len(words)-1 {
+			continue
+		}
+		if i == len(words)-1 {
+			if c, ok := ordinalWords[w]; ok {
+				w, ordinal = c, true
+			} else if strings.HasSuffix(w, "ieth") {
+				w, ordinal = strings.TrimSuffix(w, "ieth")+"y", true
+			} else if strings.HasSuffix(w, "th") {
+				w, ordinal = strings.TrimSuffix(w, "th"), true
+			}
+		}
+
+		if w == "oh" {
+			if !seen || cur < 10 || cur >= 100 || oh {
+				return 0, false, false
+			}
+			cur, oh = cur*100, true
+			continue
+		}
+
+		if v, ok := smallNumberWords[w]; ok {
+			if oh {
+				if v == 0 || v >= 10 {
+					return 0, false, false
+				}
+				cur, oh = cur+v, false
+				continue
+			}
+			switch lo := cur % 100; {
+			case !seen:
+				cur = v
+			case cur >= 100 && lo == 0:
+				// "one hundred five," "nineteen hundred five"
+				cur += v
+			case lo >= 20 && lo%10 == 0 && v < 10:
+				// "twenty one," "one hundred twenty one"
+				cur += v
+			case cur >= 10 && cur < 100:
+				// A year, as in "nineteen seventeen."
+				cur = cur*100 + v
+			default:
+				return 0, false, false
+			}
+			seen = true
+			continue
+		}
+
+		scale, ok := scaleWords[w]
+		if !ok || !seen || oh {
+			return 0, false, false
+		}
+		if scale == 100 {
+			if cur >= 100 {
+				return 0, false, false
+			}
+			cur *= 100
+			continue
+		}
+		if scale > big {
+			// A larger scale applies to everything before it,
+			// as in "one lakh twenty thousand crore."
+			total, big = (total+cur)*scale, scale
+		} else {
+			total += cur * scale
+		}
+		cur, seen = 0, false
+	}
+	if oh {
+		return 0, false, false
+	}
+	return total + cur, ordinal, true
+}
+
+var smallNumberWords = map[string]int64{
+	"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4,
+	"five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9,
+	"ten": 10, "eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14,
+	"fifteen": 15, "sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19,
+	"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+	"sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+}
+
+var scaleWords = map[string]int64{
+	"hundred":  100,
+	"thousand": 1000,
+	"lakh":     100000,
+	"million":  1000000,
+	"crore":    10000000,
+	"billion":  1000000000,
+}
+
+// ordinalWords maps irregular ordinals to their cardinals.
+var ordinalWords = map[string]string{
+	"zeroth":  "zero",
+	"first":   "one",
+	"second":  "two",
+	"third":   "three",
+	"fifth":   "five",
+	"eighth":  "eight",
+	"ninth":   "nine",
+	"twelfth": "twelve",
+}
@@ -0,0 +1,145 @@
+package bib
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWordsToInt(t *testing.T) {
+	cases := []struct {
+		inp     string
+		want    int64
+		ordinal bool
+		ok      bool
+	}{{
+		inp:  "forty-two",
+		want: 42,
+		ok:   true,
+	}, {
+		inp:     "Forty-Second",
+		want:    42,
+		ordinal: true,
+		ok:      true,
+	}, {
+		inp:  "nineteen seventeen",
+		want: 1917,
+		ok:   true,
+	}, {
+		inp:  "nineteen eighty-four",
+		want: 1984,
+		ok:   true,
+	}, {
+		inp:  "twenty twenty-one",
+		want: 2021,
+		ok:   true,
+	}, {
+		inp:  "twenty one",
+		want: 21,
+		ok:   true,
+	}, {
+		inp:  "one hundred and five",
+		want: 105,
+		ok:   true,
+	}, {
+		inp:  "five lakh",
+		want: 500000,
+		ok:   true,
+	}, {
+		inp:     "twentieth",
+		want:    20,
+		ordinal: true,
+		ok:      true,
+	}, {
+		inp:     "one millionth",
+		want:    1000000,
+		ordinal: true,
+		ok:      true,
+	}, {
+		inp: "",
+	}, {
+		inp: "hundred",
+	}, {
+		inp: "forty and",
+	}, {
+		inp: "first second",
+	}, {
+		inp: "one two",
+	}, {
+		inp: "seventh heaven",
+	}, {
+		inp:  "nineteen oh seven",
+		want: 1907,
+		ok:   true,
+	}, {
+		inp:  "Twenty-Oh-One",
+		want: 2001,
+		ok:   true,
+	}, {
+		inp: "nineteen oh",
+	}, {
+		inp: "oh seven",
+	}, {
+		inp: "nineteen oh ten",
+	}, {
+		inp: "nineteen oh oh seven",
+	}, {
+		inp: "one hundred oh seven",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			got, ordinal, ok := WordsToInt(tc.inp)
+			if got != tc.want || ordinal != tc.ordinal || ok != tc.ok {
+				t.Errorf("got %d, %v, %v; want %d, %v, %v", got, ordinal, ok, tc.want, tc.ordinal, tc.ok)
+			}
+		})
+	}
+}
+
+func TestWordsToIntRoundTrip(t *testing.T) {
+	var nums []int64
+	for n := int64(0); n < 3000; n++ {
+		nums = append(nums, n)
+	}
+	nums = append(nums, 10000, 10001, 99999, 100000, 123456, 1000000, 7654321, 1000000000, 1234567890123)
+
+	indian := NewKeyer(WithNumbering(NumberingIndian))
+	indianWords := func(n int64, ordinal bool) []string {
+		if ordinal {
+			return indian.NumberWords(n, WithOrdinal())
+		}
+		return indian.NumberWords(n)
+	}
+
+	for _, n := range nums {
+		for _, ordinal := range []bool{false, true} {
+			for _, f := range []func(int64, bool) []string{intToWords, indianWords} {
+				words := strings.Join(f(n, ordinal), " ")
+				got, gotOrdinal, ok := WordsToInt(words)
+				if got != n || gotOrdinal != ordinal || !ok {
+					t.Errorf("%q: got %d, %v, %v; want %d, %v, true", words, got, gotOrdinal, ok, n, ordinal)
+				}
+			}
+		}
+	}
+}
+
+func TestWordsToIntRoundTripOptions(t *testing.T) {
+	optSets := [][]Option{
+		{WithOhYears()},
+		{WithOhYears(), WithYearRanges(YearRange{From: 1000, To: 2999})},
+		{WithOhYears(), WithBritishAnd()},
+		{WithBritishAnd(), WithNumbering(NumberingIndian)},
+	}
+	for _, opts := range optSets {
+		k := NewKeyer(opts...)
+		for n := int64(0); n < 3000; n++ {
+			words := strings.Join(k.NumberWords(n), " ")
+			got, ordinal, ok := WordsToInt(words)
+			if got != n || ordinal || !ok {
+				t.Errorf("%q: got %d, %v, %v; want %d, false, true", words, got, ordinal, ok, n)
+			}
+		}
+	}
+}
@@ -0,0 +1,403 @@
+package bib
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// WriterOptions are options for [NewSortedWriter].
+type WriterOptions struct {
+	// Keyer computes the sort keys of the entries.
+	// If this is nil, [Default] is used.
+	// When Workers is more than 1,
+	// any functions given to the Keyer in its options
+	// (such as with [WithResolver] or [WithSegmenter])
+	// must be safe for concurrent use.
+	Keyer *Keyer
+
+	// Workers is the number of goroutines that compute keys.
+	// If this is zero, [runtime.GOMAXPROCS] is used.
+	Workers int
+
+	// ChunkSize is the number of entries to collect
+	// before computing their keys,
+	// divided among the workers.
+	// If this is zero, 1024 is used.
+	ChunkSize int
+
+	// MaxMemory is the approximate number of bytes of entries and keys
+	// to hold in memory before spilling them to a temporary file.
+	// If this is zero, 64 MiB is used.
+	MaxMemory int
+}
+
+const (
+	defaultChunkSize = 1024
+	defaultMaxMemory = 64 << 20
+)
+
+// SortedWriter collects entries
+// and, when closed,
+// writes them to an underlying [io.Writer] in bibliographic order,
+// one per line.
+// Entries whose keys are equal keep the order in which they were written.
+//
+// Written bytes are split into entries at newlines,
+// however they are divided among calls to Write,
+// so a SortedWriter can be the destination of e.g. [io.Copy].
+// A final line without a newline is an entry too.
+// To add an entry directly, use [SortedWriter.Add].
+//
+// When the entries outgrow memory (see [WriterOptions.MaxMemory]),
+// a SortedWriter sorts the ones it has
+// and moves them to a temporary file,
+// merging all such files when it is closed.
+type SortedWriter struct {
+	w       io.Writer
+	k       *Keyer
+	workers int
+	chunk   int
+	maxMem  int
+
+	partial []byte   // the start of a line not yet ended by a newline
+	pending []string // entries whose keys are not yet computed
+	entries []KeyedString
+	mem     int
+	runs    []*os.File
+	err     error
+	closed  bool
+}
+
+// ErrClosed is the error for writing to a [SortedWriter] that has been closed.
+var ErrClosed = errors.New("write to closed SortedWriter")
+
+// NewSortedWriter produces a [SortedWriter] that writes to w.
+func NewSortedWriter(w io.Writer, opts WriterOptions) *SortedWriter {
+	sw := &SortedWriter{
+		w:       w,
+		k:       opts.Keyer,
+		workers: opts.Workers,
+		chunk:   opts.ChunkSize,
+		maxMem:  opts.MaxMemory,
+	}
+	if sw.k == nil {
+		sw.k = Default()
+	}
+	if sw.workers <= 0 {
+		sw.workers = runtime.GOMAXPROCS(0)
+	}
+	if sw.chunk <= 0 {
+		sw.chunk = defaultChunkSize
+	}
+	if sw.maxMem <= 0 {
+		sw.maxMem = defaultMaxMemory
+	}
+	return sw
+}
+
+// Write adds each line of p to the entries.
+// The last line, if it has no newline,
+// is held until the next call to Write, [SortedWriter.WriteString], or [SortedWriter.Close]
+// completes it.
+func (sw *SortedWriter) Write(p []byte) (int, error) {
+	return sw.WriteString(string(p))
+}
+
+// WriteString is like [SortedWriter.Write] but takes a string.
+// It returns an error only if an attempt to spill entries to a temporary file failed,
+// or if sw has been closed.
+func (sw *SortedWriter) WriteString(s string) (int, error) {
+	if sw.closed {
+		return 0, ErrClosed
+	}
+	if sw.err != nil {
+		return 0, sw.err
+	}
+
+	n := len(s)
+	for {
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			break
+		}
+		line := s[:i]
+		if len(sw.partial) > 0 {
+			line = string(sw.partial) + line
+			sw.partial = sw.partial[:0]
+		}
+		if err := sw.add(line); err != nil {
+			return 0, err
+		}
+		s = s[i+1:]
+	}
+	sw.partial = append(sw.partial, s...)
+	return n, nil
+}
+
+// Add adds s to the entries as a single entry,
+// whether or not it contains newlines.
+// A trailing newline is not part of the entry.
+// It returns an error only if an attempt to spill entries to a temporary file failed,
+// or if sw has been closed.
+func (sw *SortedWriter) Add(s string) error {
+	if sw.closed {
+		return ErrClosed
+	}
+	if sw.err != nil {
+		return sw.err
+	}
+	return sw.add(strings.TrimSuffix(s, "\n"))
+}
+
+// add adds the entry s,
+// less any trailing carriage return.
+func (sw *SortedWriter) add(s string) error {
+	s = strings.TrimSuffix(s, "\r")
+	sw.pending = append(sw.pending, s)
+	sw.mem += len(s)
+	if len(sw.pending) >= sw.chunk || sw.mem > sw.maxMem {
+		sw.computeKeys()
+	}
+	if sw.mem > sw.maxMem {
+		sw.err = sw.spill()
+	}
+	return sw.err
+}
+
+// computeKeys computes the keys of the pending entries,
+// dividing them among the workers.
+func (sw *SortedWriter) computeKeys() {
+	var (
+		n    = len(sw.pending)
+		keys = make([]string, n)
+		per  = (n + sw.workers - 1) / sw.workers
+		wg   sync.WaitGroup
+	)
+	for lo := 0; lo < n; lo += per {
+		hi := min(lo+per, n)
+		if lo == 0 && hi == n {
+			// No need for a goroutine.
+			for i := lo; i < hi; i++ {
+				keys[i] = sw.k.Key(sw.pending[i])
+			}
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := lo; i < hi; i++ {
+				keys[i] = sw.k.Key(sw.pending[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, s := range sw.pending {
+		sw.entries = append(sw.entries, KeyedString{Orig: s, Key: keys[i]})
+		sw.mem += len(keys[i])
+	}
+	sw.pending = sw.pending[:0]
+}
+
+// spill sorts the entries in memory
+// and writes them to a new temporary file.
+func (sw *SortedWriter) spill() error {
+	sw.k.sortKeyed(sw.entries)
+
+	f, err := os.CreateTemp("", "bibsort-*")
+	if err != nil {
+		return fmt.Errorf("creating temporary file: %w", err)
+	}
+	sw.runs = append(sw.runs, f)
+
+	var (
+		bw  = bufio.NewWriter(f)
+		buf []byte
+	)
+	for _, e := range sw.entries {
+		buf = binary.AppendUvarint(buf[:0], uint64(len(e.Key)))
+		buf = append(buf, e.Key...)
+		buf = binary.AppendUvarint(buf, uint64(len(e.Orig)))
+		buf = append(buf, e.Orig...)
+		if _, err := bw.Write(buf); err != nil {
+			return fmt.Errorf("writing temporary file: %w", err)
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("writing temporary file: %w", err)
+	}
+
+	sw.entries, sw.mem = nil, 0
+	return nil
+}
+
+// Close adds any final line that has no newline to the entries,
+// writes the sorted entries to the underlying writer,
+// and removes any temporary files.
+// It does not close the underlying writer.
+func (sw *SortedWriter) Close() error {
+	if sw.closed {
+		return ErrClosed
+	}
+	if sw.err == nil && len(sw.partial) > 0 {
+		sw.add(string(sw.partial))
+		sw.partial = nil
+	}
+	sw.closed = true
+	defer sw.removeRuns()
+
+	if sw.err != nil {
+		return sw.err
+	}
+
+	sw.computeKeys()
+
+	bw := bufio.NewWriter(sw.w)
+	if len(sw.runs) == 0 {
+		sw.k.sortKeyed(sw.entries)
+		for _, e := range sw.entries {
+			bw.WriteString(e.Orig)
+			bw.WriteByte('\n')
+		}
+		sw.entries = nil
+		return bw.Flush()
+	}
+
+	if len(sw.entries) > 0 {
+		if err := sw.spill(); err != nil {
+			return err
+		}
+	}
+	if err := sw.merge(bw); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func (sw *SortedWriter) removeRuns() {
+	for _, f := range sw.runs {
+		f.Close()
+		os.Remove(f.Name())
+	}
+	sw.runs = nil
+}
+
+// merge merges the sorted runs in the temporary files into w.
+// Among entries with equal keys,
+// those from earlier runs come first,
+// preserving the order in which they were written.
+func (sw *SortedWriter) merge(w *bufio.Writer) error {
+	h := runHeap{k: sw.k}
+	for i, f := range sw.runs {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("rewinding temporary file: %w", err)
+		}
+		r := &run{index: i, r: bufio.NewReader(f)}
+		ok, err := r.next()
+		if err != nil {
+			return err
+		}
+		if ok {
+			h.runs = append(h.runs, r)
+		}
+	}
+	heap.Init(&h)
+
+	for len(h.runs) > 0 {
+		r := h.runs[0]
+		w.WriteString(r.cur.Orig)
+		w.WriteByte('\n')
+		ok, err := r.next()
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+		}
+	}
+	return nil
+}
+
+// run is a sorted run of entries in a temporary file.
+type run struct {
+	index int
+	r     *bufio.Reader
+	cur   KeyedString
+}
+
+// next reads the next entry in the run into r.cur.
+// It returns false at the end of the run.
+func (r *run) next() (bool, error) {
+	key, err := r.readString()
+	if err == io.EOF {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	text, err := r.readString()
+	if err == io.EOF {
+		err = unexpectedEOF(err)
+	}
+	if err != nil {
+		return false, err
+	}
+	r.cur = KeyedString{Orig: text, Key: key}
+	return true, nil
+}
+
+func (r *run) readString() (string, error) {
+	n, err := binary.ReadUvarint(r.r)
+	if err == io.EOF {
+		return "", err
+	}
+	if err != nil {
+		return "", unexpectedEOF(err)
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r.r, buf); err != nil {
+		return "", unexpectedEOF(err)
+	}
+	return string(buf), nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("reading temporary file: %w", err)
+}
+
+type runHeap struct {
+	k    *Keyer
+	runs []*run
+}
+
+func (h runHeap) Len() int { return len(h.runs) }
+
+func (h runHeap) Less(i, j int) bool {
+	if c := h.k.compareKeyed(h.runs[i].cur, h.runs[j].cur); c != 0 {
+		return c < 0
+	}
+	return h.runs[i].index < h.runs[j].index
+}
+
+func (h runHeap) Swap(i, j int) { h.runs[i], h.runs[j] = h.runs[j], h.runs[i] }
+
+func (h *runHeap) Push(x any) { h.runs = append(h.runs, x.(*run)) }
+
+func (h *runHeap) Pop() any {
+	r := h.runs[len(h.runs)-1]
+	h.runs = h.runs[:len(h.runs)-1]
+	return r
+}
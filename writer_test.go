@@ -0,0 +1,117 @@
+package bib
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSortedWriter(t *testing.T) {
+	inp := []string{"The Zoo", "10 Cats\n", "A Bee", "ten cats", "", "Aardvark", "Ten Cats\r", "!!!"}
+	want := "\n!!!\nAardvark\nA Bee\n10 Cats\nten cats\nTen Cats\nThe Zoo\n"
+
+	cases := []WriterOptions{
+		{},
+		{MaxMemory: 1},  // spill after every entry
+		{MaxMemory: 20}, // spill after every few entries
+		{Workers: 1, ChunkSize: 1},
+		{Workers: 4, ChunkSize: 3},
+		{Workers: 3, ChunkSize: 100, MaxMemory: 30},
+	}
+
+	for i, opts := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			tmpdir := t.TempDir()
+			t.Setenv("TMPDIR", tmpdir)
+
+			buf := new(strings.Builder)
+			sw := NewSortedWriter(buf, opts)
+			for j, s := range inp {
+				var err error
+				switch j % 3 {
+				case 0:
+					_, err = sw.Write([]byte(s + "\n"))
+				case 1:
+					err = sw.Add(s)
+				case 2:
+					_, err = sw.WriteString(s + "\n")
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := sw.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+
+			if leftovers, _ := filepath.Glob(filepath.Join(tmpdir, "*")); len(leftovers) > 0 {
+				t.Errorf("temporary files remain: %v", leftovers)
+			}
+
+			if _, err := sw.WriteString("x"); err != ErrClosed {
+				t.Errorf("got error %v after Close, want ErrClosed", err)
+			}
+			if err := sw.Add("x"); err != ErrClosed {
+				t.Errorf("got error %v from Add after Close, want ErrClosed", err)
+			}
+		})
+	}
+}
+
+func TestSortedWriterKeyer(t *testing.T) {
+	buf := new(strings.Builder)
+	sw := NewSortedWriter(buf, WriterOptions{Keyer: NewKeyer(WithPlacement(PlaceLast))})
+	for _, s := range []string{"", "Zoo", "Ant"} {
+		sw.Add(s)
+	}
+	if err := sw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "Ant\nZoo\n\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSortedWriterLines(t *testing.T) {
+	cases := []struct {
+		writes []string
+		want   string
+	}{{
+		writes: []string{"The Zoo\nAard", "vark\r\n10 Ca", "ts"},
+		want:   "Aardvark\n10 Cats\nThe Zoo\n",
+	}, {
+		writes: []string{"Zoo\n\nAnt\n"},
+		want:   "\nAnt\nZoo\n",
+	}, {
+		writes: []string{"Zoo", "", "\n", "Ant"},
+		want:   "Ant\nZoo\n",
+	}, {
+		writes: []string{"One Line"},
+		want:   "One Line\n",
+	}, {
+		writes: []string{""},
+		want:   "",
+	}}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%02d", i+1), func(t *testing.T) {
+			buf := new(strings.Builder)
+			sw := NewSortedWriter(buf, WriterOptions{})
+			for _, w := range tc.writes {
+				if n, err := sw.Write([]byte(w)); err != nil || n != len(w) {
+					t.Fatalf("writing %q: got %d, %v", w, n, err)
+				}
+			}
+			if err := sw.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,444 @@
+// Package xlsx sorts the rows of Excel (.xlsx) worksheets bibliographically.
+//
+// Sorting rearranges the worksheet's row elements byte for byte,
+// renumbering them and their cells for their new positions,
+// so that cell values, styles, and row heights travel with their rows
+// and everything else in the workbook is left exactly as it was.
+//
+// Formulas, merged cells, and other ranges that refer to particular rows
+// are not adjusted,
+// so sheets with such references to the sorted rows
+// may not survive sorting intact.
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bobg/bib"
+	"github.com/bobg/bib/internal/xmlsplice"
+)
+
+// Options controls [Sort].
+type Options struct {
+	// Sheet is the name of the worksheet to sort.
+	// If this is empty, the first worksheet is sorted.
+	Sheet string
+
+	// Column identifies the column to sort by:
+	// either its letters ("B")
+	// or, if Header is true, the text of its header cell.
+	// If this is empty, column A is used.
+	Column string
+
+	// Header means that the first row is a header,
+	// which stays in place.
+	Header bool
+
+	// Key converts cell values to sort keys.
+	// If this is nil, [bib.Key] is used.
+	Key func(string) string
+}
+
+// Sort reads an .xlsx workbook from r,
+// whose size is given,
+// sorts the rows of one of its worksheets,
+// and writes the resulting workbook to w.
+// Rows whose keys are equal keep their original relative order,
+// and rows whose sort column is empty come first.
+func Sort(r io.ReaderAt, size int64, w io.Writer, opts Options) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return fmt.Errorf("opening workbook: %w", err)
+	}
+
+	sheetPath, err := findSheet(zr, opts.Sheet)
+	if err != nil {
+		return err
+	}
+	strs, err := readSharedStrings(zr)
+	if err != nil {
+		return err
+	}
+	data, err := readFile(zr, sheetPath)
+	if err != nil {
+		return err
+	}
+	sorted, err := sortSheet(data, strs, opts)
+	if err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(w)
+	for _, f := range zr.File {
+		if f.Name != sheetPath {
+			if err := zw.Copy(f); err != nil {
+				return fmt.Errorf("copying %s: %w", f.Name, err)
+			}
+			continue
+		}
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: f.Name, Method: f.Method, Modified: f.Modified})
+		if err != nil {
+			return fmt.Errorf("writing %s: %w", f.Name, err)
+		}
+		if _, err := fw.Write(sorted); err != nil {
+			return fmt.Errorf("writing %s: %w", f.Name, err)
+		}
+	}
+	return zw.Close()
+}
+
+// ErrNoSheet is the error returned by [Sort] when the requested worksheet is not found.
+var ErrNoSheet = errors.New("worksheet not found")
+
+// findSheet returns the path in the archive of the named worksheet,
+// or the first one if name is empty.
+func findSheet(zr *zip.Reader, name string) (string, error) {
+	wb, err := readFile(zr, "xl/workbook.xml")
+	if err != nil {
+		return "", err
+	}
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := xml.Unmarshal(wb, &workbook); err != nil {
+		return "", fmt.Errorf("parsing workbook: %w", err)
+	}
+
+	var rid string
+	for _, s := range workbook.Sheets {
+		if name == "" || s.Name == name {
+			rid = s.RID
+			break
+		}
+	}
+	if rid == "" {
+		if name == "" {
+			return "", ErrNoSheet
+		}
+		return "", fmt.Errorf("%s: %w", name, ErrNoSheet)
+	}
+
+	rels, err := readFile(zr, "xl/_rels/workbook.xml.rels")
+	if err != nil {
+		return "", err
+	}
+	var relationships struct {
+		Rels []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := xml.Unmarshal(rels, &relationships); err != nil {
+		return "", fmt.Errorf("parsing workbook relationships: %w", err)
+	}
+	for _, rel := range relationships.Rels {
+		if rel.ID != rid {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return "", fmt.Errorf("no relationship %s for worksheet: %w", rid, ErrNoSheet)
+}
+
+// readSharedStrings reads the workbook's shared-string table, if any.
+func readSharedStrings(zr *zip.Reader) ([]string, error) {
+	data, err := readFile(zr, "xl/sharedStrings.xml")
+	if errors.Is(err, errNotInArchive) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		strs  []string
+		cur   strings.Builder
+		inT   bool
+		inRPh bool // phonetic runs are not part of the string
+		dec   = xml.NewDecoder(bytes.NewReader(data))
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return strs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing shared strings: %w", err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			switch tok.Name.Local {
+			case "si":
+				cur.Reset()
+			case "t":
+				inT = true
+			case "rPh":
+				inRPh = true
+			}
+		case xml.EndElement:
+			switch tok.Name.Local {
+			case "si":
+				strs = append(strs, cur.String())
+			case "t":
+				inT = false
+			case "rPh":
+				inRPh = false
+			}
+		case xml.CharData:
+			if inT && !inRPh {
+				cur.Write(tok)
+			}
+		}
+	}
+}
+
+var errNotInArchive = errors.New("not in archive")
+
+func readFile(zr *zip.Reader, name string) ([]byte, error) {
+	for _, f := range zr.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("opening %s: %w", name, err)
+		}
+		defer rc.Close()
+		data, err := io.ReadAll(rc)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("%s: %w", name, errNotInArchive)
+}
+
+// row is one row element of a worksheet.
+type row struct {
+	num   int               // the row number
+	xml   []byte            // the row element, verbatim
+	cells map[string]string // cell values by column letters
+}
+
+// sortSheet sorts the rows of a worksheet document.
+func sortSheet(data []byte, strs []string, opts Options) ([]byte, error) {
+	var (
+		rows  []*row
+		slots [][2]int
+		dec   = xml.NewDecoder(bytes.NewReader(data))
+		depth int
+		inSD  bool // inside sheetData
+	)
+	for {
+		start := dec.InputOffset()
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing worksheet: %w", err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if inSD && tok.Name.Local == "row" {
+				r, err := readRow(dec, tok, strs, len(rows))
+				if err != nil {
+					return nil, err
+				}
+				end := int(dec.InputOffset())
+				r.xml = data[start:end]
+				rows = append(rows, r)
+				slots = append(slots, [2]int{int(start), end})
+				continue
+			}
+			depth++
+			if depth == 2 && tok.Name.Local == "sheetData" {
+				inSD = true
+			}
+		case xml.EndElement:
+			if inSD && tok.Name.Local == "sheetData" {
+				inSD = false
+			}
+			depth--
+		}
+	}
+
+	if len(rows) == 0 {
+		return data, nil
+	}
+	var header *row
+	if opts.Header {
+		header, rows, slots = rows[0], rows[1:], slots[1:]
+	}
+
+	col := strings.ToUpper(opts.Column)
+	if col == "" {
+		col = "A"
+	}
+	if opts.Header && opts.Column != "" {
+		found := false
+		for c, v := range header.cells {
+			if strings.TrimSpace(v) == opts.Column && (!found || columnNumber(c) < columnNumber(col)) {
+				col, found = c, true
+			}
+		}
+		if !found && !isColumnLetters(col) {
+			return nil, fmt.Errorf("no column %s", opts.Column)
+		}
+	} else if !isColumnLetters(col) {
+		return nil, fmt.Errorf("bad column %s", opts.Column)
+	}
+
+	keyFn := opts.Key
+	if keyFn == nil {
+		keyFn = bib.Key
+	}
+	nums := make([]int, len(rows))
+	keys := make(map[*row]string, len(rows))
+	for i, r := range rows {
+		nums[i] = r.num
+		v := r.cells[col]
+		keys[r] = keyFn(v)
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return keys[rows[i]] < keys[rows[j]] })
+
+	parts := make([][]byte, len(rows))
+	for i, r := range rows {
+		parts[i] = renumber(r.xml, nums[i])
+	}
+	return xmlsplice.Splice(data, slots, parts), nil
+}
+
+// readRow consumes tokens through the end of a row element,
+// whose start element is given,
+// and returns its number and cell values.
+// The index of the row in the sheet is used to number it
+// if it has no number of its own.
+func readRow(dec *xml.Decoder, start xml.StartElement, strs []string, index int) (*row, error) {
+	r := &row{num: index + 1, cells: make(map[string]string)}
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "r" {
+			if n, err := strconv.Atoi(attr.Value); err == nil {
+				r.num = n
+			}
+		}
+	}
+
+	var (
+		depth   = 1
+		colNum  int
+		cellCol string
+		cellTyp string
+		val     strings.Builder
+		inVal   bool
+	)
+	for depth > 0 {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("parsing row %d: %w", r.num, err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			depth++
+			switch {
+			case depth == 2 && tok.Name.Local == "c":
+				colNum++
+				cellCol, cellTyp = columnName(colNum), ""
+				val.Reset()
+				for _, attr := range tok.Attr {
+					switch attr.Name.Local {
+					case "r":
+						cellCol = strings.TrimRight(strings.ReplaceAll(attr.Value, "$", ""), "0123456789")
+						colNum = columnNumber(cellCol)
+					case "t":
+						cellTyp = attr.Value
+					}
+				}
+			case tok.Name.Local == "v", tok.Name.Local == "t" && cellTyp == "inlineStr":
+				inVal = true
+			}
+
+		case xml.EndElement:
+			switch {
+			case depth == 2 && tok.Name.Local == "c":
+				v := val.String()
+				if cellTyp == "s" {
+					if i, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && i >= 0 && i < len(strs) {
+						v = strs[i]
+					}
+				}
+				r.cells[cellCol] = v
+			case tok.Name.Local == "v", tok.Name.Local == "t":
+				inVal = false
+			}
+			depth--
+
+		case xml.CharData:
+			if inVal {
+				val.Write(tok)
+			}
+		}
+	}
+	return r, nil
+}
+
+var (
+	rowNumRegex  = regexp.MustCompile(`^(<(?:[\w.-]+:)?row\b[^>]*?\sr=")(\d+)(")`)
+	cellRefRegex = regexp.MustCompile(`(<(?:[\w.-]+:)?c\b[^>]*?\sr="\$?[A-Z]+\$?)(\d+)(")`)
+)
+
+// renumber changes the number of a row element,
+// and the references of its cells, to num.
+func renumber(rowXML []byte, num int) []byte {
+	repl := []byte("${1}" + strconv.Itoa(num) + "${3}")
+	rowXML = rowNumRegex.ReplaceAll(rowXML, repl)
+	return cellRefRegex.ReplaceAll(rowXML, repl)
+}
+
+func isColumnLetters(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// columnName converts a 1-based column number to its letters.
+func columnName(n int) string {
+	var b []byte
+	for n > 0 {
+		n--
+		b = append([]byte{byte('A' + n%26)}, b...)
+		n /= 26
+	}
+	return string(b)
+}
+
+// columnNumber converts column letters to a 1-based column number.
+func columnNumber(s string) int {
+	n := 0
+	for _, c := range s {
+		n = n*26 + int(c-'A') + 1
+	}
+	return n
+}
@@ -0,0 +1,133 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+)
+
+const (
+	workbookXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Notes" sheetId="1" r:id="rId1"/><sheet name="Titles" sheetId="2" r:id="rId2"/></sheets></workbook>`
+
+	relsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet2.xml"/></Relationships>`
+
+	sharedStringsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><si><t>Title</t></si><si><t>Year</t></si><si><t>The Gumball Rally</t></si><si><r><t>42nd </t></r><r><rPr><b/></rPr><t>Street</t></r></si><si><t>Alien</t></si></sst>`
+
+	notesXML = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>unchanged</t></is></c></row></sheetData></worksheet>`
+
+	titlesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><dimension ref="A1:B5"/><sheetData>` +
+		`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>` +
+		`<row r="2" ht="20" customHeight="1"><c r="A2" t="s" s="3"><v>2</v></c><c r="B2"><v>1976</v></c></row>` +
+		`<row r="3"><c r="A3" t="s"><v>3</v></c><c r="B3"><v>1933</v></c></row>` +
+		`<row r="5"><c r="A5" t="inlineStr"><is><t>Casablanca</t></is></c><c r="B5"><v>1942</v></c></row>` +
+		`<row r="6"><c r="A6" t="s"><v>4</v></c><c r="B6"><v>1979</v></c></row>` +
+		`</sheetData><pageMargins left="0.7"/></worksheet>`
+)
+
+func makeWorkbook(t *testing.T) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	for _, f := range []struct{ name, content string }{
+		{"[Content_Types].xml", "<Types/>"},
+		{"xl/workbook.xml", workbookXML},
+		{"xl/_rels/workbook.xml.rels", relsXML},
+		{"xl/sharedStrings.xml", sharedStringsXML},
+		{"xl/worksheets/sheet1.xml", notesXML},
+		{"xl/worksheets/sheet2.xml", titlesXML},
+	} {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(w, f.content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSort(t *testing.T) {
+	cases := []struct {
+		name string
+		opts Options
+		want string
+	}{{
+		name: "by title",
+		opts: Options{Sheet: "Titles", Column: "Title", Header: true},
+		want: `<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>` +
+			`<row r="2"><c r="A2" t="s"><v>4</v></c><c r="B2"><v>1979</v></c></row>` +
+			`<row r="3"><c r="A3" t="inlineStr"><is><t>Casablanca</t></is></c><c r="B3"><v>1942</v></c></row>` +
+			`<row r="5"><c r="A5" t="s"><v>3</v></c><c r="B5"><v>1933</v></c></row>` +
+			`<row r="6" ht="20" customHeight="1"><c r="A6" t="s" s="3"><v>2</v></c><c r="B6"><v>1976</v></c></row>`,
+	}, {
+		name: "by year",
+		opts: Options{Sheet: "Titles", Column: "B", Header: true, Key: func(s string) string { return s }},
+		want: `<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>` +
+			`<row r="2"><c r="A2" t="s"><v>3</v></c><c r="B2"><v>1933</v></c></row>` +
+			`<row r="3"><c r="A3" t="inlineStr"><is><t>Casablanca</t></is></c><c r="B3"><v>1942</v></c></row>` +
+			`<row r="5" ht="20" customHeight="1"><c r="A5" t="s" s="3"><v>2</v></c><c r="B5"><v>1976</v></c></row>` +
+			`<row r="6"><c r="A6" t="s"><v>4</v></c><c r="B6"><v>1979</v></c></row>`,
+	}}
+
+	src := makeWorkbook(t)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := new(bytes.Buffer)
+			if err := Sort(bytes.NewReader(src), int64(len(src)), out, tc.opts); err != nil {
+				t.Fatal(err)
+			}
+			zr, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(zr.File) != 6 {
+				t.Fatalf("got %d files, want 6", len(zr.File))
+			}
+
+			notes, err := readFile(zr, "xl/worksheets/sheet1.xml")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(notes) != notesXML {
+				t.Errorf("other worksheet changed: %s", notes)
+			}
+
+			titles, err := readFile(zr, "xl/worksheets/sheet2.xml")
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := titlesXML[:bytes.Index([]byte(titlesXML), []byte("<row"))] + tc.want + `</sheetData><pageMargins left="0.7"/></worksheet>`
+			if string(titles) != want {
+				t.Errorf("got:\n%s\nwant:\n%s", titles, want)
+			}
+		})
+	}
+}
+
+func TestNoSheet(t *testing.T) {
+	src := makeWorkbook(t)
+	err := Sort(bytes.NewReader(src), int64(len(src)), io.Discard, Options{Sheet: "Missing"})
+	if err == nil {
+		t.Fatal("got no error")
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for n, want := range map[int]string{1: "A", 26: "Z", 27: "AA", 52: "AZ", 703: "AAA"} {
+		if got := columnName(n); got != want {
+			t.Errorf("columnName(%d) = %s, want %s", n, got, want)
+		}
+		if got := columnNumber(want); got != n {
+			t.Errorf("columnNumber(%s) = %d, want %d", want, got, n)
+		}
+	}
+}